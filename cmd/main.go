@@ -2,35 +2,133 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	i "github.com/Piyush01Bhatt/interpreter_go/internal/interpreter"
 	psr "github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	rs "github.com/Piyush01Bhatt/interpreter_go/internal/resolver"
 	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
 )
 
 func runFile(filePath string) {
-	file, err := os.Open(filePath)
+	source, err := os.ReadFile(filePath)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		fmt.Println("Error reading file:", err)
+		os.Exit(66)
 	}
-	defer file.Close()
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		fmt.Println(scanner.Text()) // Read and print each line
+
+	statements, ok := parseSource(string(source))
+	if !ok {
+		os.Exit(65)
 	}
 
-	if err := scanner.Err(); err != nil {
-		fmt.Println("Error reading file:", err)
+	interpreter := i.NewInterpreter(i.ModeFile)
+	if err := configureModules(interpreter, filePath); err != nil {
+		fmt.Println("Error resolving script path:", err)
+		os.Exit(66)
+	}
+	resolver := rs.NewResolver(interpreter)
+	resolveErrors := resolver.Resolve(statements)
+	reportResolveWarnings(resolver.Warnings())
+	if len(resolveErrors) > 0 {
+		reportResolveErrors(resolveErrors)
+		os.Exit(65)
+	}
+
+	if err := interpreter.Interpret(statements); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(70)
+	}
+}
+
+// parseSource scans and parses source, reporting whether both phases succeeded.
+func parseSource(source string) ([]psr.Stmt, bool) {
+	lexScanner := ls.NewLexScanner(source)
+	tokens, scanErrors := lexScanner.ScanTokens()
+	if len(scanErrors) > 0 {
+		reportScanErrors(scanErrors)
+		return nil, false
+	}
+
+	parser := psr.NewParser(tokens)
+	statements, parseErrors := parser.Parse()
+	if len(parseErrors) > 0 {
+		reportParseErrors(parseErrors)
+		return nil, false
+	}
+	return statements, true
+}
+
+// configureModules lets the script at scriptPath, or the REPL when it is
+// empty, import modules, looking relative paths up next to the importing
+// file and then in the directories listed in LOX_PATH.
+func configureModules(interpreter *i.Interpreter, scriptPath string) error {
+	searchPath := filepath.SplitList(os.Getenv("LOX_PATH"))
+	return interpreter.ConfigureModules(func(source string) ([]psr.Stmt, error) {
+		lexScanner := ls.NewLexScanner(source)
+		tokens, scanErrors := lexScanner.ScanTokens()
+		if len(scanErrors) > 0 {
+			return nil, joinErrors(scanErrors)
+		}
+
+		parser := psr.NewParser(tokens)
+		statements, parseErrors := parser.Parse()
+		if len(parseErrors) > 0 {
+			return nil, joinErrors(parseErrors)
+		}
+
+		resolver := rs.NewResolver(interpreter)
+		resolveErrors := resolver.Resolve(statements)
+		reportResolveWarnings(resolver.Warnings())
+		if len(resolveErrors) > 0 {
+			return nil, joinErrors(resolveErrors)
+		}
+		return statements, nil
+	}, scriptPath, searchPath)
+}
+
+func joinErrors[E error](errs []E) error {
+	joined := make([]error, len(errs))
+	for idx, err := range errs {
+		joined[idx] = err
+	}
+	return errors.Join(joined...)
+}
+
+func reportScanErrors(scanErrors []ls.ScanError) {
+	for _, err := range scanErrors {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+func reportParseErrors(parseErrors []psr.ParseError) {
+	for _, err := range parseErrors {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+func reportResolveErrors(resolveErrors []rs.ResolveError) {
+	for _, err := range resolveErrors {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+func reportResolveWarnings(warnings []rs.ResolveWarning) {
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, warning)
 	}
 }
 
 func runPrompt() {
 	reader := bufio.NewReader(os.Stdin)
 	interpreter := i.NewInterpreter(i.ModePrompt)
+	if err := configureModules(interpreter, ""); err != nil {
+		fmt.Println("Error configuring imports:", err)
+	}
 	for {
 		fmt.Print(">> ")                      // Display prompt
 		input, err := reader.ReadString('\n') // Read input until Enter (newline)
@@ -46,17 +144,34 @@ func runPrompt() {
 		}
 
 		lexScanner := ls.NewLexScanner(input)
-		tokens := lexScanner.ScanTokens()
+		tokens, scanErrors := lexScanner.ScanTokens()
+		if len(scanErrors) > 0 {
+			reportScanErrors(scanErrors)
+			continue
+		}
 
 		parser := psr.NewParser(tokens)
-		statements := parser.Parse()
+		statements, parseErrors := parser.Parse()
+		if len(parseErrors) > 0 {
+			reportParseErrors(parseErrors)
+			continue
+		}
 
-		interpreter.Interpret(statements)
+		resolver := rs.NewResolver(interpreter)
+		resolveErrors := resolver.Resolve(statements)
+		reportResolveWarnings(resolver.Warnings())
+		if len(resolveErrors) > 0 {
+			reportResolveErrors(resolveErrors)
+			continue
+		}
+
+		if err := interpreter.Interpret(statements); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
 	}
 }
 
 func main() {
-	fmt.Println("This is the main function")
 	args := os.Args[1:]
 
 	if len(args) > 1 {
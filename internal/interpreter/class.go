@@ -0,0 +1,94 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+// Class is a runtime class. Calling it creates an Instance and runs the
+// class's init method, if any, against it.
+type Class struct {
+	Name       string
+	superclass *Class
+	methods    map[string]*Function
+}
+
+func NewClass(name string, superclass *Class, methods map[string]*Function) *Class {
+	return &Class{
+		Name:       name,
+		superclass: superclass,
+		methods:    methods,
+	}
+}
+
+// FindMethod returns the method called name, searching up the superclass
+// chain, or nil.
+func (c *Class) FindMethod(name string) *Function {
+	if method, ok := c.methods[name]; ok {
+		return method
+	}
+	if c.superclass != nil {
+		return c.superclass.FindMethod(name)
+	}
+	return nil
+}
+
+func (c *Class) Arity() int {
+	if initializer := c.FindMethod("init"); initializer != nil {
+		return initializer.Arity()
+	}
+	return 0
+}
+
+func (c *Class) Call(paren *ls.Token, arguments []*parser.Value) *parser.Value {
+	instance := NewInstance(c)
+	if initializer := c.FindMethod("init"); initializer != nil {
+		initializer.Bind(instance).Call(paren, arguments)
+	}
+	return parser.NewObjectValue(instance)
+}
+
+func (c *Class) String() string {
+	return fmt.Sprintf("<class %s>", c.Name)
+}
+
+// Instance is an object created from a Class. Fields shadow methods.
+type Instance struct {
+	class  *Class
+	fields map[string]*parser.Value
+}
+
+func NewInstance(class *Class) *Instance {
+	return &Instance{
+		class:  class,
+		fields: make(map[string]*parser.Value),
+	}
+}
+
+func (in *Instance) Get(name *ls.Token) *parser.Value {
+	if value, ok := in.fields[name.Lexeme]; ok {
+		return value
+	}
+	if method := in.class.FindMethod(name.Lexeme); method != nil {
+		return parser.NewCallableValue(method.Bind(in))
+	}
+	panic(newRuntimeError(name, "Undefined property '"+name.Lexeme+"'."))
+}
+
+// Has reports whether name is a field or method of the instance.
+func (in *Instance) Has(name string) bool {
+	if _, ok := in.fields[name]; ok {
+		return true
+	}
+	return in.class.FindMethod(name) != nil
+}
+
+func (in *Instance) Set(name *ls.Token, value *parser.Value) {
+	in.fields[name.Lexeme] = value
+}
+
+func (in *Instance) String() string {
+	return fmt.Sprintf("<%s instance>", in.class.Name)
+}
@@ -0,0 +1,182 @@
+package interpreter
+
+import (
+	"strings"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+func (i *Interpreter) VisitListLiteral(expr *parser.ListLiteral) *parser.Value {
+	elements := make([]*parser.Value, len(expr.Elements))
+	for idx, element := range expr.Elements {
+		elements[idx] = element.Accept(i)
+	}
+	return parser.NewListValue(elements)
+}
+
+func (i *Interpreter) VisitMapLiteral(expr *parser.MapLiteral) *parser.Value {
+	m := parser.NewMap()
+	for idx, keyExpr := range expr.Keys {
+		key := keyExpr.Accept(i)
+		value := expr.Values[idx].Accept(i)
+		if !m.Set(key, value) {
+			panic(newRuntimeError(expr.LeftBrace, "Unhashable map key of type "+key.GetType()+"."))
+		}
+	}
+	return parser.NewMapValue(m)
+}
+
+func (i *Interpreter) VisitIndex(expr *parser.Index) *parser.Value {
+	object := expr.Object.Accept(i)
+	index := expr.Index.Accept(i)
+	return i.getIndex(expr.Bracket, object, index)
+}
+
+func (i *Interpreter) VisitIndexSet(expr *parser.IndexSet) *parser.Value {
+	object := expr.Object.Accept(i)
+	index := expr.Index.Accept(i)
+	var current *parser.Value
+	if expr.Operator != nil {
+		current = i.getIndex(expr.Bracket, object, index)
+	}
+	value := expr.Value.Accept(i)
+	if expr.Operator != nil {
+		value = i.evaluateBinaryOp(current, value, expr.Operator)
+	}
+	i.setIndex(expr.Bracket, object, index, value)
+	return value
+}
+
+func (i *Interpreter) VisitSlice(expr *parser.Slice) *parser.Value {
+	object := expr.Object.Accept(i)
+	var start, end *parser.Value
+	if expr.Start != nil {
+		start = expr.Start.Accept(i)
+	}
+	if expr.End != nil {
+		end = expr.End.Accept(i)
+	}
+
+	switch {
+	case object.IsList():
+		elements := object.ListVal.Elements
+		from, to := i.sliceBounds(expr.Bracket, start, end, len(elements))
+		sliced := make([]*parser.Value, to-from)
+		copy(sliced, elements[from:to])
+		return parser.NewListValue(sliced)
+	case object.IsString():
+		chars := []rune(*object.StrVal)
+		from, to := i.sliceBounds(expr.Bracket, start, end, len(chars))
+		return parser.NewStringValue(string(chars[from:to]))
+	}
+	panic(newRuntimeError(expr.Bracket, "Only lists and strings can be sliced."))
+}
+
+// getIndex reads a map entry, a list element or a single character of a
+// string.
+func (i *Interpreter) getIndex(bracket *ls.Token, object, index *parser.Value) *parser.Value {
+	switch {
+	case object.IsMap():
+		value, ok := object.MapVal.Get(index)
+		if !ok {
+			panic(newRuntimeError(bracket, "Undefined key "+index.String()+"."))
+		}
+		return value
+	case object.IsList():
+		elements := object.ListVal.Elements
+		return elements[i.elementIndex(bracket, index, len(elements))]
+	case object.IsString():
+		chars := []rune(*object.StrVal)
+		return parser.NewStringValue(string(chars[i.elementIndex(bracket, index, len(chars))]))
+	}
+	panic(newRuntimeError(bracket, "Only maps, lists and strings can be indexed."))
+}
+
+func (i *Interpreter) setIndex(bracket *ls.Token, object, index, value *parser.Value) {
+	if object.IsMap() {
+		if !object.MapVal.Set(index, value) {
+			panic(newRuntimeError(bracket, "Unhashable map key of type "+index.GetType()+"."))
+		}
+		return
+	}
+	if !object.IsList() {
+		panic(newRuntimeError(bracket, "Only map entries and list elements can be assigned."))
+	}
+	elements := object.ListVal.Elements
+	elements[i.elementIndex(bracket, index, len(elements))] = value
+}
+
+// elementIndex turns index into a position within a sequence of length n.
+// Negative indices count back from the end.
+func (i *Interpreter) elementIndex(bracket *ls.Token, index *parser.Value, n int) int {
+	if !index.IsInt() {
+		panic(newRuntimeError(bracket, "Index must be an integer."))
+	}
+	idx := *index.IntVal
+	if idx < 0 {
+		idx += n
+	}
+	if idx < 0 || idx >= n {
+		panic(newRuntimeError(bracket, "Index out of range."))
+	}
+	return idx
+}
+
+// sliceBounds resolves optional slice bounds against a sequence of
+// length n. Negative bounds count back from the end and bounds past
+// either end are clamped, so slicing never fails on range.
+func (i *Interpreter) sliceBounds(bracket *ls.Token, start, end *parser.Value, n int) (int, int) {
+	bound := func(value *parser.Value, fallback int) int {
+		if value == nil || value.IsNil() {
+			return fallback
+		}
+		if !value.IsInt() {
+			panic(newRuntimeError(bracket, "Slice bounds must be integers."))
+		}
+		idx := *value.IntVal
+		if idx < 0 {
+			idx += n
+		}
+		return max(0, min(idx, n))
+	}
+	from, to := bound(start, 0), bound(end, n)
+	if to < from {
+		to = from
+	}
+	return from, to
+}
+
+// contains implements "in": key membership for maps, element membership
+// for lists and substring search for strings.
+func (i *Interpreter) contains(operator *ls.Token, needle, haystack *parser.Value) *parser.Value {
+	switch {
+	case haystack.IsMap():
+		return parser.NewBoolValue(haystack.MapVal.Has(needle))
+	case haystack.IsList():
+		for _, element := range haystack.ListVal.Elements {
+			if element.Equals(needle) {
+				return parser.NewBoolValue(true)
+			}
+		}
+		return parser.NewBoolValue(false)
+	case haystack.IsString():
+		if !needle.IsString() {
+			panic(newRuntimeError(operator, "Left operand of 'in' must be a string when searching a string."))
+		}
+		return parser.NewBoolValue(strings.Contains(*haystack.StrVal, *needle.StrVal))
+	case haystack.IsRange():
+		r := haystack.RangeVal
+		return parser.NewBoolValue(needle.IsInt() && *needle.IntVal >= r.Start && *needle.IntVal < r.End)
+	}
+	panic(newRuntimeError(operator, "Right operand of 'in' must be a map, list, string or range."))
+}
+
+func (i *Interpreter) VisitRange(expr *parser.RangeExpr) *parser.Value {
+	start := expr.Start.Accept(i)
+	end := expr.End.Accept(i)
+	if !start.IsInt() || !end.IsInt() {
+		panic(newRuntimeError(expr.Operator, "Range bounds must be integers."))
+	}
+	return parser.NewRangeValue(*start.IntVal, *end.IntVal)
+}
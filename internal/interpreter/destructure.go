@@ -0,0 +1,75 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+func (i *Interpreter) VisitDestructureStmt(stmt *parser.DestructureStmt) *parser.Value {
+	value := stmt.Expr.Accept(i)
+	var values []*parser.Value
+	if stmt.Record {
+		values = i.unpackRecord(stmt.Open, stmt.Names, value)
+	} else {
+		values = i.unpackList(stmt.Open, len(stmt.Names), value)
+	}
+
+	for idx, name := range stmt.Names {
+		if stmt.Const {
+			i.environment.DefineConst(name, values[idx])
+		} else {
+			i.environment.Define(name, values[idx])
+		}
+	}
+	return value
+}
+
+func (i *Interpreter) VisitDestructureAssign(expr *parser.DestructureAssign) *parser.Value {
+	value := expr.Value.Accept(i)
+	values := i.unpackList(expr.Bracket, len(expr.Targets), value)
+	for idx, target := range expr.Targets {
+		i.assignVariable(target.Name, target, values[idx])
+	}
+	return value
+}
+
+// unpackList returns the elements of value, which must be a list of
+// exactly count elements.
+func (i *Interpreter) unpackList(bracket *ls.Token, count int, value *parser.Value) []*parser.Value {
+	if !value.IsList() {
+		panic(newRuntimeError(bracket, "Can only destructure a list with '[...]', got "+value.GetType()+"."))
+	}
+	elements := value.ListVal.Elements
+	if len(elements) != count {
+		panic(newRuntimeError(bracket,
+			fmt.Sprintf("Expected a list of %d elements to destructure but got %d.", count, len(elements))))
+	}
+	values := make([]*parser.Value, count)
+	copy(values, elements)
+	return values
+}
+
+// unpackRecord returns the value under each of names, read as string keys
+// of a map or as properties of an object.
+func (i *Interpreter) unpackRecord(brace *ls.Token, names []*ls.Token, value *parser.Value) []*parser.Value {
+	values := make([]*parser.Value, len(names))
+	switch {
+	case value.IsMap():
+		for idx, name := range names {
+			element, ok := value.MapVal.Get(parser.NewStringValue(name.Lexeme))
+			if !ok {
+				panic(newRuntimeError(name, "Map has no key '"+name.Lexeme+"' to destructure."))
+			}
+			values[idx] = element
+		}
+	case value.IsObject():
+		for idx, name := range names {
+			values[idx] = value.ObjectVal.Get(name)
+		}
+	default:
+		panic(newRuntimeError(brace, "Can only destructure a map or object with '{...}', got "+value.GetType()+"."))
+	}
+	return values
+}
@@ -0,0 +1,79 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+// Enum is the value an enum declaration binds. Its properties are the
+// members, and iterating it yields them in declaration order.
+type Enum struct {
+	Name    string
+	members []*EnumMember
+	byName  map[string]*EnumMember
+}
+
+func NewEnum(name string, memberNames []string) *Enum {
+	enum := &Enum{
+		Name:   name,
+		byName: make(map[string]*EnumMember, len(memberNames)),
+	}
+	for ordinal, memberName := range memberNames {
+		member := &EnumMember{enum: enum, Name: memberName, Ordinal: ordinal}
+		enum.members = append(enum.members, member)
+		enum.byName[memberName] = member
+	}
+	return enum
+}
+
+func (e *Enum) Get(name *ls.Token) *parser.Value {
+	if member, ok := e.byName[name.Lexeme]; ok {
+		return parser.NewObjectValue(member)
+	}
+	panic(newRuntimeError(name, "Enum '"+e.Name+"' has no member '"+name.Lexeme+"'."))
+}
+
+func (e *Enum) Set(name *ls.Token, value *parser.Value) {
+	panic(newRuntimeError(name, "Can't add members to enum '"+e.Name+"'."))
+}
+
+func (e *Enum) String() string {
+	return fmt.Sprintf("<enum %s>", e.Name)
+}
+
+// EnumMember is one value of an Enum. Members are only equal to
+// themselves, and expose read-only "name" and "ordinal" properties.
+type EnumMember struct {
+	enum    *Enum
+	Name    string
+	Ordinal int
+}
+
+func (m *EnumMember) Get(name *ls.Token) *parser.Value {
+	switch name.Lexeme {
+	case "name":
+		return parser.NewStringValue(m.Name)
+	case "ordinal":
+		return parser.NewIntValue(m.Ordinal)
+	}
+	panic(newRuntimeError(name, "Undefined property '"+name.Lexeme+"'."))
+}
+
+func (m *EnumMember) Set(name *ls.Token, value *parser.Value) {
+	panic(newRuntimeError(name, "Can't set properties on enum members."))
+}
+
+func (m *EnumMember) String() string {
+	return m.enum.Name + "." + m.Name
+}
+
+func (i *Interpreter) VisitEnumStmt(stmt *parser.EnumStmt) *parser.Value {
+	names := make([]string, len(stmt.Members))
+	for idx, member := range stmt.Members {
+		names[idx] = member.Lexeme
+	}
+	i.environment.DefineConst(stmt.Name, parser.NewObjectValue(NewEnum(stmt.Name.Lexeme, names)))
+	return nil
+}
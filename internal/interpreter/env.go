@@ -2,10 +2,18 @@ package interpreter
 
 import (
 	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
 )
 
+// Env holds the variables of one lexical scope. Lookups that miss walk
+// outward through enclosing scopes; the global scope has none.
 type Env struct {
-	values map[string]*parser.Value
+	values    map[string]*parser.Value
+	constants map[string]bool
+	enclosing *Env
+	// redefinable lets a declaration replace a constant, so REPL input can
+	// redeclare globals from earlier lines.
+	redefinable bool
 }
 
 func NewEnv() *Env {
@@ -14,17 +22,88 @@ func NewEnv() *Env {
 	}
 }
 
-func (e *Env) Define(name string, value *parser.Value) {
-	e.values[name] = value
+func NewEnclosedEnv(enclosing *Env) *Env {
+	return &Env{
+		values:    make(map[string]*parser.Value),
+		enclosing: enclosing,
+	}
 }
 
-func (e *Env) Get(name string) *parser.Value {
-	return e.values[name]
+// Define binds name to value, replacing any earlier variable of that
+// name in this scope. Replacing a constant is an error unless the scope
+// is redefinable.
+func (e *Env) Define(name *ls.Token, value *parser.Value) {
+	e.checkRedefinition(name)
+	e.values[name.Lexeme] = value
+	delete(e.constants, name.Lexeme)
+}
+
+// DefineConst binds name to value and refuses later assignments to it.
+func (e *Env) DefineConst(name *ls.Token, value *parser.Value) {
+	e.checkRedefinition(name)
+	e.values[name.Lexeme] = value
+	if e.constants == nil {
+		e.constants = make(map[string]bool)
+	}
+	e.constants[name.Lexeme] = true
 }
 
-func (e *Env) Assign(name string, value *parser.Value) {
-	if _, ok := e.values[name]; !ok {
-		panic("Undefined variable '" + name + "'.")
+func (e *Env) checkRedefinition(name *ls.Token) {
+	if e.constants[name.Lexeme] && !e.redefinable {
+		panic(newRuntimeError(name, "Can't redeclare constant '"+name.Lexeme+"'."))
 	}
+}
+
+// bind defines a name the interpreter introduces itself, such as "this"
+// or a native, which can't collide with a constant.
+func (e *Env) bind(name string, value *parser.Value) {
 	e.values[name] = value
 }
+
+func (e *Env) Get(name *ls.Token) *parser.Value {
+	value, ok := e.values[name.Lexeme]
+	if !ok {
+		if e.enclosing != nil {
+			return e.enclosing.Get(name)
+		}
+		panic(newRuntimeError(name, "Undefined variable '"+name.Lexeme+"'."))
+	}
+	return value
+}
+
+func (e *Env) Assign(name *ls.Token, value *parser.Value) {
+	if _, ok := e.values[name.Lexeme]; !ok {
+		if e.enclosing != nil {
+			e.enclosing.Assign(name, value)
+			return
+		}
+		panic(newRuntimeError(name, "Undefined variable '"+name.Lexeme+"'."))
+	}
+	e.set(name, value)
+}
+
+// GetAt reads name from the scope distance levels out, as computed by
+// the resolver.
+func (e *Env) GetAt(distance int, name string) *parser.Value {
+	return e.ancestor(distance).values[name]
+}
+
+// AssignAt writes name in the scope distance levels out.
+func (e *Env) AssignAt(distance int, name *ls.Token, value *parser.Value) {
+	e.ancestor(distance).set(name, value)
+}
+
+func (e *Env) set(name *ls.Token, value *parser.Value) {
+	if e.constants[name.Lexeme] {
+		panic(newRuntimeError(name, "Can't assign to constant '"+name.Lexeme+"'."))
+	}
+	e.values[name.Lexeme] = value
+}
+
+func (e *Env) ancestor(distance int) *Env {
+	env := e
+	for range distance {
+		env = env.enclosing
+	}
+	return env
+}
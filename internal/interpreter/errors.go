@@ -0,0 +1,89 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+// ErrorObject is how a RuntimeError raised by the interpreter appears to
+// a catch clause. Its read-only "message" and "line" properties describe
+// the failure, and throwing it again re-raises the original error.
+type ErrorObject struct {
+	err RuntimeError
+}
+
+func (e *ErrorObject) Get(name *ls.Token) *parser.Value {
+	switch name.Lexeme {
+	case "message":
+		return parser.NewStringValue(e.err.Message)
+	case "line":
+		return parser.NewIntValue(e.err.Token.Line)
+	}
+	panic(newRuntimeError(name, "Undefined property '"+name.Lexeme+"'."))
+}
+
+func (e *ErrorObject) Set(name *ls.Token, value *parser.Value) {
+	panic(newRuntimeError(name, "Can't set properties on errors."))
+}
+
+func (e *ErrorObject) String() string {
+	return fmt.Sprintf("<error: %s>", e.err.Message)
+}
+
+func (i *Interpreter) VisitThrowStmt(stmt *parser.ThrowStmt) *parser.Value {
+	value := stmt.Value.Accept(i)
+	if value.IsObject() {
+		if caught, ok := value.ObjectVal.(*ErrorObject); ok {
+			panic(caught.err)
+		}
+	}
+	panic(RuntimeError{
+		Token:   stmt.Keyword,
+		Message: "Uncaught " + value.String() + ".",
+		Value:   value,
+	})
+}
+
+func (i *Interpreter) VisitTryStmt(stmt *parser.TryStmt) *parser.Value {
+	if stmt.Finally != nil {
+		// Deferred so the finally block also runs when the try or catch
+		// body returns or raises. An error raised here replaces theirs.
+		defer func() {
+			i.executeBlock(stmt.Finally, NewEnclosedEnv(i.environment))
+		}()
+	}
+
+	if stmt.Catch == nil {
+		i.executeBlock(stmt.Body, NewEnclosedEnv(i.environment))
+		return nil
+	}
+	if caught := i.tryBlock(stmt.Body); caught != nil {
+		env := NewEnclosedEnv(i.environment)
+		env.Define(stmt.CatchName, caught)
+		i.executeBlock(stmt.Catch, env)
+	}
+	return nil
+}
+
+// tryBlock runs body and returns the value to bind in a catch clause if a
+// RuntimeError escaped it, or nil if it completed. Other panics, such as
+// returnSignal, pass through.
+func (i *Interpreter) tryBlock(body []parser.Stmt) (caught *parser.Value) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(RuntimeError)
+			if !ok {
+				panic(r)
+			}
+			caught = err.Value
+			if caught == nil {
+				caught = parser.NewObjectValue(&ErrorObject{err: err})
+			}
+		}
+	}()
+
+	i.executeBlock(body, NewEnclosedEnv(i.environment))
+	return nil
+}
@@ -0,0 +1,117 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+// maxCallDepth bounds nested calls to user functions, so runaway recursion
+// raises a RuntimeError before it exhausts the Go stack, which can't be
+// recovered from.
+const maxCallDepth = 10000
+
+// returnSignal carries a return value up to the enclosing Function.Call.
+// It is raised with panic so it unwinds any nested blocks and loops.
+type returnSignal struct {
+	keyword *ls.Token
+	value   *parser.Value
+}
+
+// Function is a user-defined function together with the environment it
+// was declared in, so free variables resolve lexically.
+type Function struct {
+	declaration   *parser.FunctionStmt
+	closure       *Env
+	globals       *Env
+	interpreter   *Interpreter
+	isInitializer bool
+}
+
+// NewFunction also captures the globals of the module being run, so that
+// the function sees them when called from another module.
+func NewFunction(declaration *parser.FunctionStmt, closure *Env, interpreter *Interpreter, isInitializer bool) *Function {
+	return &Function{
+		declaration:   declaration,
+		closure:       closure,
+		globals:       interpreter.globals,
+		interpreter:   interpreter,
+		isInitializer: isInitializer,
+	}
+}
+
+// Bind returns a copy of the method whose closure defines "this" as instance.
+func (f *Function) Bind(instance *Instance) *Function {
+	env := NewEnclosedEnv(f.closure)
+	env.bind("this", parser.NewObjectValue(instance))
+	bound := NewFunction(f.declaration, env, f.interpreter, f.isInitializer)
+	bound.globals = f.globals
+	return bound
+}
+
+func (f *Function) Arity() int {
+	return len(f.declaration.Params)
+}
+
+func (f *Function) Call(paren *ls.Token, arguments []*parser.Value) (result *parser.Value) {
+	env := NewEnclosedEnv(f.closure)
+	for idx, param := range f.declaration.Params {
+		env.Define(param, arguments[idx])
+	}
+
+	if f.interpreter.callDepth >= maxCallDepth {
+		panic(newRuntimeError(paren, "Stack overflow."))
+	}
+	f.interpreter.callDepth++
+
+	previousGlobals := f.interpreter.globals
+	f.interpreter.globals = f.globals
+	defer func() {
+		f.interpreter.callDepth--
+		f.interpreter.globals = previousGlobals
+		if r := recover(); r != nil {
+			ret, ok := r.(returnSignal)
+			if !ok {
+				panic(r)
+			}
+			result = ret.value
+			if f.isInitializer {
+				result = f.closure.GetAt(0, "this")
+			}
+		}
+	}()
+
+	f.interpreter.executeBlock(f.declaration.Body, env)
+	if f.isInitializer {
+		return f.closure.GetAt(0, "this")
+	}
+	return parser.NewNilValue()
+}
+
+func (f *Function) String() string {
+	if f.declaration.Name == nil {
+		return "<fn>"
+	}
+	return fmt.Sprintf("<fn %s>", f.declaration.Name.Lexeme)
+}
+
+// NativeFunction exposes a Go function to scripts. Fn may raise a
+// RuntimeError with panic, using paren to locate the call.
+type NativeFunction struct {
+	Name   string
+	Params int
+	Fn     func(paren *ls.Token, arguments []*parser.Value) *parser.Value
+}
+
+func (n *NativeFunction) Arity() int {
+	return n.Params
+}
+
+func (n *NativeFunction) Call(paren *ls.Token, arguments []*parser.Value) *parser.Value {
+	return n.Fn(paren, arguments)
+}
+
+func (n *NativeFunction) String() string {
+	return fmt.Sprintf("<native fn %s>", n.Name)
+}
@@ -1,7 +1,9 @@
 package interpreter
 
 import (
+	"cmp"
 	"fmt"
+	"math"
 
 	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
 	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
@@ -14,16 +16,65 @@ const (
 	ModeFile
 )
 
+// RuntimeError is raised while evaluating a program. Token locates the
+// operator or name that triggered it. Value holds the operand of a throw
+// statement and is nil for errors raised by the interpreter itself.
+type RuntimeError struct {
+	Token   *ls.Token
+	Message string
+	Value   *parser.Value
+}
+
+func (e RuntimeError) Error() string {
+	return fmt.Sprintf("[line %d] %s", e.Token.Line, e.Message)
+}
+
+// newRuntimeError builds a RuntimeError; raise it with panic so that
+// Interpret can recover it.
+func newRuntimeError(token *ls.Token, message string) RuntimeError {
+	return RuntimeError{
+		Token:   token,
+		Message: message,
+	}
+}
+
+// Interpreter evaluates resolved statements. Each module, including the
+// main script, has its own globals enclosing a shared scope of natives;
+// globals is the one belonging to the code running now.
 type Interpreter struct {
+	builtins    *Env
+	globals     *Env
 	environment *Env
+	locals      map[parser.Expr]int
 	mode        ExecutionMode
+	modules     moduleLoader
+	callDepth   int
 }
 
 func NewInterpreter(mode ExecutionMode) *Interpreter {
-	return &Interpreter{
-		environment: NewEnv(),
+	builtins := NewEnv()
+	globals := NewEnclosedEnv(builtins)
+	globals.redefinable = mode == ModePrompt
+	interpreter := &Interpreter{
+		builtins:    builtins,
+		globals:     globals,
+		environment: globals,
+		locals:      make(map[parser.Expr]int),
 		mode:        mode,
+		modules: moduleLoader{
+			baseDir: ".",
+			cache:   make(map[string]*Module),
+		},
 	}
+	interpreter.defineNatives()
+	return interpreter
+}
+
+// Resolve records that expr refers to a local declared depth scopes out
+// from where it is evaluated. Unresolved names are looked up as globals
+// of the current module.
+func (i *Interpreter) Resolve(expr parser.Expr, depth int) {
+	i.locals[expr] = depth
 }
 
 // Implement ExprVisitor
@@ -44,20 +95,139 @@ func (i *Interpreter) VisitLiteral(expr *parser.Literal) *parser.Value {
 }
 
 func (i *Interpreter) VisitVariable(expr *parser.Variable) *parser.Value {
-	value := i.environment.Get(expr.Name)
-	if value.IsNil() {
-		// Could raise an error here instead
-		return parser.NewNilValue()
-	}
-	return value
+	return i.lookUpVariable(expr.Name, expr)
 }
 
 func (i *Interpreter) VisitAssign(expr *parser.Assign) *parser.Value {
+	var current *parser.Value
+	if expr.Operator != nil {
+		current = i.lookUpVariable(expr.Name, expr)
+	}
 	value := expr.Expr.Accept(i)
-	i.environment.Assign(expr.Name, value)
+	if expr.Operator != nil {
+		value = i.evaluateBinaryOp(current, value, expr.Operator)
+	}
+	i.assignVariable(expr.Name, expr, value)
+	return value
+}
+
+// VisitLogical short-circuits, yielding whichever operand decided the result.
+func (i *Interpreter) VisitLogical(expr *parser.Logical) *parser.Value {
+	left := expr.Left.Accept(i)
+	if expr.Operator.Type == ls.OR {
+		if left.IsTruthy() {
+			return left
+		}
+	} else if !left.IsTruthy() {
+		return left
+	}
+	return expr.Right.Accept(i)
+}
+
+func (i *Interpreter) VisitCall(expr *parser.Call) *parser.Value {
+	callee := expr.Callee.Accept(i)
+
+	arguments := make([]*parser.Value, len(expr.Arguments))
+	for idx, arg := range expr.Arguments {
+		arguments[idx] = arg.Accept(i)
+	}
+
+	return i.call(expr.Paren, callee, arguments)
+}
+
+func (i *Interpreter) VisitLambda(expr *parser.Lambda) *parser.Value {
+	return parser.NewCallableValue(NewFunction(expr.Function, i.environment, i, false))
+}
+
+func (i *Interpreter) VisitGet(expr *parser.Get) *parser.Value {
+	object := expr.Object.Accept(i)
+	if !object.IsObject() {
+		panic(newRuntimeError(expr.Name, "Only instances have properties."))
+	}
+	return object.ObjectVal.Get(expr.Name)
+}
+
+func (i *Interpreter) VisitSet(expr *parser.Set) *parser.Value {
+	object := expr.Object.Accept(i)
+	if !object.IsObject() {
+		panic(newRuntimeError(expr.Name, "Only instances have fields."))
+	}
+	var current *parser.Value
+	if expr.Operator != nil {
+		current = object.ObjectVal.Get(expr.Name)
+	}
+	value := expr.Value.Accept(i)
+	if expr.Operator != nil {
+		value = i.evaluateBinaryOp(current, value, expr.Operator)
+	}
+	object.ObjectVal.Set(expr.Name, value)
 	return value
 }
 
+func (i *Interpreter) VisitThis(expr *parser.This) *parser.Value {
+	return i.lookUpVariable(expr.Keyword, expr)
+}
+
+func (i *Interpreter) VisitSuper(expr *parser.Super) *parser.Value {
+	distance := i.locals[expr]
+	superclass := i.environment.GetAt(distance, "super").CallableVal.(*Class)
+	// "this" is always bound in the scope just inside the one holding "super".
+	instance := i.environment.GetAt(distance-1, "this").ObjectVal.(*Instance)
+
+	method := superclass.FindMethod(expr.Method.Lexeme)
+	if method == nil {
+		panic(newRuntimeError(expr.Method, "Undefined property '"+expr.Method.Lexeme+"'."))
+	}
+	return parser.NewCallableValue(method.Bind(instance))
+}
+
+func (i *Interpreter) VisitConditional(expr *parser.Conditional) *parser.Value {
+	if expr.Condition.Accept(i).IsTruthy() {
+		return expr.ThenBranch.Accept(i)
+	}
+	return expr.ElseBranch.Accept(i)
+}
+
+func (i *Interpreter) VisitUpdate(expr *parser.Update) *parser.Value {
+	step := func(old *parser.Value) *parser.Value {
+		i.checkNumberOperand(expr.Operator, old)
+		delta := parser.NewIntValue(1)
+		if expr.Operator.Type == ls.MINUS_MINUS {
+			delta = parser.NewIntValue(-1)
+		}
+		return i.arithmetic(old, delta,
+			func(a, b int) int { return a + b },
+			func(a, b float64) float64 { return a + b })
+	}
+
+	var old, updated *parser.Value
+	switch target := expr.Target.(type) {
+	case *parser.Variable:
+		old = i.lookUpVariable(target.Name, target)
+		updated = step(old)
+		i.assignVariable(target.Name, target, updated)
+	case *parser.Get:
+		object := target.Object.Accept(i)
+		if !object.IsObject() {
+			panic(newRuntimeError(target.Name, "Only instances have fields."))
+		}
+		old = object.ObjectVal.Get(target.Name)
+		updated = step(old)
+		object.ObjectVal.Set(target.Name, updated)
+	case *parser.Index:
+		object := target.Object.Accept(i)
+		index := target.Index.Accept(i)
+		old = i.getIndex(target.Bracket, object, index)
+		updated = step(old)
+		i.setIndex(target.Bracket, object, index, updated)
+	}
+
+	if expr.Prefix {
+		return updated
+	}
+	return old
+}
+
 // Implement StmtVisitor
 func (i *Interpreter) VisitExpressionStmt(stmt *parser.ExpressionStmt) *parser.Value {
 	return stmt.Expr.Accept(i)
@@ -76,133 +246,438 @@ func (i *Interpreter) VisitVarStmt(stmt *parser.VarStmt) *parser.Value {
 	} else {
 		value = parser.NewNilValue()
 	}
-	i.environment.Define(stmt.Name.Lexeme, value)
+	if stmt.Const {
+		i.environment.DefineConst(stmt.Name, value)
+	} else {
+		i.environment.Define(stmt.Name, value)
+	}
 	return value
 }
 
+func (i *Interpreter) VisitIfStmt(stmt *parser.IfStmt) *parser.Value {
+	if stmt.Condition.Accept(i).IsTruthy() {
+		stmt.ThenBranch.Accept(i)
+	} else if stmt.ElseBranch != nil {
+		stmt.ElseBranch.Accept(i)
+	}
+	return nil
+}
+
+func (i *Interpreter) VisitWhileStmt(stmt *parser.WhileStmt) *parser.Value {
+	for stmt.Condition.Accept(i).IsTruthy() {
+		stmt.Body.Accept(i)
+	}
+	return nil
+}
+
+func (i *Interpreter) VisitForInStmt(stmt *parser.ForInStmt) *parser.Value {
+	iterator := i.iterate(stmt.Keyword, stmt.Iterable.Accept(i))
+	for {
+		value, ok := iterator.Next()
+		if !ok {
+			return nil
+		}
+		env := NewEnclosedEnv(i.environment)
+		env.Define(stmt.Name, value)
+		i.executeBlock([]parser.Stmt{stmt.Body}, env)
+	}
+}
+
+func (i *Interpreter) VisitFunctionStmt(stmt *parser.FunctionStmt) *parser.Value {
+	function := NewFunction(stmt, i.environment, i, false)
+	i.environment.Define(stmt.Name, parser.NewCallableValue(function))
+	return nil
+}
+
+func (i *Interpreter) VisitReturnStmt(stmt *parser.ReturnStmt) *parser.Value {
+	value := parser.NewNilValue()
+	if stmt.Value != nil {
+		value = stmt.Value.Accept(i)
+	}
+	panic(returnSignal{keyword: stmt.Keyword, value: value})
+}
+
+func (i *Interpreter) VisitClassStmt(stmt *parser.ClassStmt) *parser.Value {
+	var superclass *Class
+	if stmt.Superclass != nil {
+		value := stmt.Superclass.Accept(i)
+		class, ok := value.CallableVal.(*Class)
+		if !ok {
+			panic(newRuntimeError(stmt.Superclass.Name, "Superclass must be a class."))
+		}
+		superclass = class
+	}
+
+	i.environment.Define(stmt.Name, parser.NewNilValue())
+
+	// Methods of a subclass close over an extra scope holding "super".
+	methodEnv := i.environment
+	if superclass != nil {
+		methodEnv = NewEnclosedEnv(i.environment)
+		methodEnv.bind("super", parser.NewCallableValue(superclass))
+	}
+
+	methods := make(map[string]*Function, len(stmt.Methods))
+	for _, method := range stmt.Methods {
+		methods[method.Name.Lexeme] = NewFunction(method, methodEnv, i, method.Name.Lexeme == "init")
+	}
+	class := NewClass(stmt.Name.Lexeme, superclass, methods)
+	i.environment.Define(stmt.Name, parser.NewCallableValue(class))
+	return nil
+}
+
+func (i *Interpreter) VisitBlockStmt(stmt *parser.BlockStmt) *parser.Value {
+	i.executeBlock(stmt.Stmts, NewEnclosedEnv(i.environment))
+	return nil
+}
+
+// executeBlock runs stmts in env, restoring the previous environment
+// afterwards even if a runtime error unwinds through it.
+func (i *Interpreter) executeBlock(stmts []parser.Stmt, env *Env) {
+	previous := i.environment
+	defer func() {
+		i.environment = previous
+	}()
+
+	i.environment = env
+	for _, stmt := range stmts {
+		stmt.Accept(i)
+	}
+}
+
 // Helper methods for operations
 func (i *Interpreter) evaluateBinaryOp(left, right *parser.Value, operator *ls.Token) *parser.Value {
 	switch operator.Type {
 	case ls.PLUS:
-		return i.add(left, right)
+		return i.add(operator, left, right)
 	case ls.MINUS:
-		return i.subtract(left, right)
+		return i.subtract(operator, left, right)
 	case ls.STAR:
-		return i.multiply(left, right)
+		return i.multiply(operator, left, right)
 	case ls.SLASH:
-		return i.divide(left, right)
+		return i.divide(operator, left, right)
+	case ls.TILDE_SLASH:
+		return i.floorDivide(operator, left, right)
+	case ls.PERCENT:
+		return i.modulo(operator, left, right)
+	case ls.STAR_STAR:
+		return i.power(operator, left, right)
+	case ls.AMPERSAND, ls.PIPE, ls.CARET, ls.LESS_LESS, ls.GREATER_GREATER:
+		return i.bitwise(operator, left, right)
 	case ls.GREATER:
-		return i.greater(left, right)
+		return i.greater(operator, left, right)
 	case ls.GREATER_EQUAL:
-		return i.greaterEqual(left, right)
+		return i.greaterEqual(operator, left, right)
 	case ls.LESS:
-		return i.less(left, right)
+		return i.less(operator, left, right)
 	case ls.LESS_EQUAL:
-		return i.lessEqual(left, right)
+		return i.lessEqual(operator, left, right)
 	case ls.EQUAL_EQUAL:
 		return i.equal(left, right)
 	case ls.BANG_EQUAL:
 		return i.notEqual(left, right)
+	case ls.IN:
+		return i.contains(operator, left, right)
 	default:
-		panic(fmt.Sprintf("Unknown binary operator: %s", operator.Lexeme))
+		panic(newRuntimeError(operator, fmt.Sprintf("Unknown binary operator: %s", operator.Lexeme)))
 	}
 }
 
 func (i *Interpreter) evaluateUnaryOp(right *parser.Value, operator *ls.Token) *parser.Value {
 	switch operator.Type {
 	case ls.MINUS:
-		return i.negate(right)
+		return i.negate(operator, right)
 	case ls.BANG:
 		return i.logicalNot(right)
+	case ls.TILDE:
+		return i.bitwiseNot(operator, right)
 	default:
-		panic(fmt.Sprintf("Unknown unary operator: %s", operator.Lexeme))
+		panic(newRuntimeError(operator, fmt.Sprintf("Unknown unary operator: %s", operator.Lexeme)))
 	}
 }
 
 // Operation implementations
-func (i *Interpreter) add(left, right *parser.Value) *parser.Value {
+//
+// Arithmetic on two ints yields an int and wraps around on overflow, as
+// Go's int does. If either operand is a float the other is promoted and
+// the result is a float. Division always yields a float; floor division
+// and modulo round towards negative infinity, so the remainder takes the
+// sign of the divisor.
+func (i *Interpreter) add(operator *ls.Token, left, right *parser.Value) *parser.Value {
 	if left.IsNumber() && right.IsNumber() {
-		return parser.NewFloatValue(left.ToFloat64() + right.ToFloat64())
+		return i.arithmetic(left, right,
+			func(a, b int) int { return a + b },
+			func(a, b float64) float64 { return a + b })
 	}
 	if left.IsString() && right.IsString() {
 		return parser.NewStringValue(*left.StrVal + *right.StrVal)
 	}
-	panic("Operands must be two numbers or two strings")
+	panic(newRuntimeError(operator, "Operands must be two numbers or two strings"))
 }
 
-func (i *Interpreter) subtract(left, right *parser.Value) *parser.Value {
-	i.checkNumberOperands(left, right)
-	return parser.NewFloatValue(left.ToFloat64() - right.ToFloat64())
+func (i *Interpreter) subtract(operator *ls.Token, left, right *parser.Value) *parser.Value {
+	i.checkNumberOperands(operator, left, right)
+	return i.arithmetic(left, right,
+		func(a, b int) int { return a - b },
+		func(a, b float64) float64 { return a - b })
 }
 
-func (i *Interpreter) multiply(left, right *parser.Value) *parser.Value {
-	i.checkNumberOperands(left, right)
-	return parser.NewFloatValue(left.ToFloat64() * right.ToFloat64())
+func (i *Interpreter) multiply(operator *ls.Token, left, right *parser.Value) *parser.Value {
+	i.checkNumberOperands(operator, left, right)
+	return i.arithmetic(left, right,
+		func(a, b int) int { return a * b },
+		func(a, b float64) float64 { return a * b })
 }
 
-func (i *Interpreter) divide(left, right *parser.Value) *parser.Value {
-	i.checkNumberOperands(left, right)
+func (i *Interpreter) divide(operator *ls.Token, left, right *parser.Value) *parser.Value {
+	i.checkNumberOperands(operator, left, right)
 	return parser.NewFloatValue(left.ToFloat64() / right.ToFloat64())
 }
 
-func (i *Interpreter) greater(left, right *parser.Value) *parser.Value {
-	i.checkNumberOperands(left, right)
-	return parser.NewBoolValue(left.ToFloat64() > right.ToFloat64())
+func (i *Interpreter) floorDivide(operator *ls.Token, left, right *parser.Value) *parser.Value {
+	i.checkNumberOperands(operator, left, right)
+	if left.IsInt() && right.IsInt() {
+		a, b := *left.IntVal, *right.IntVal
+		if b == 0 {
+			panic(newRuntimeError(operator, "Division by zero."))
+		}
+		q := a / b
+		if a%b != 0 && (a < 0) != (b < 0) {
+			q--
+		}
+		return parser.NewIntValue(q)
+	}
+	return parser.NewFloatValue(math.Floor(left.ToFloat64() / right.ToFloat64()))
 }
 
-func (i *Interpreter) greaterEqual(left, right *parser.Value) *parser.Value {
-	i.checkNumberOperands(left, right)
-	return parser.NewBoolValue(left.ToFloat64() >= right.ToFloat64())
+func (i *Interpreter) modulo(operator *ls.Token, left, right *parser.Value) *parser.Value {
+	i.checkNumberOperands(operator, left, right)
+	if left.IsInt() && right.IsInt() {
+		a, b := *left.IntVal, *right.IntVal
+		if b == 0 {
+			panic(newRuntimeError(operator, "Division by zero."))
+		}
+		m := a % b
+		if m != 0 && (m < 0) != (b < 0) {
+			m += b
+		}
+		return parser.NewIntValue(m)
+	}
+	a, b := left.ToFloat64(), right.ToFloat64()
+	m := math.Mod(a, b)
+	if m != 0 && (m < 0) != (b < 0) {
+		m += b
+	}
+	return parser.NewFloatValue(m)
 }
 
-func (i *Interpreter) less(left, right *parser.Value) *parser.Value {
-	i.checkNumberOperands(left, right)
-	return parser.NewBoolValue(left.ToFloat64() < right.ToFloat64())
+// power raises an int to a non-negative int exponent exactly (wrapping on
+// overflow); any other combination is computed in floating point.
+func (i *Interpreter) power(operator *ls.Token, left, right *parser.Value) *parser.Value {
+	i.checkNumberOperands(operator, left, right)
+	if left.IsInt() && right.IsInt() && *right.IntVal >= 0 {
+		base, exp, result := *left.IntVal, *right.IntVal, 1
+		for exp > 0 {
+			if exp&1 == 1 {
+				result *= base
+			}
+			base *= base
+			exp >>= 1
+		}
+		return parser.NewIntValue(result)
+	}
+	return parser.NewFloatValue(math.Pow(left.ToFloat64(), right.ToFloat64()))
 }
 
-func (i *Interpreter) lessEqual(left, right *parser.Value) *parser.Value {
-	i.checkNumberOperands(left, right)
-	return parser.NewBoolValue(left.ToFloat64() <= right.ToFloat64())
+// bitwise implements & | ^ << >>, which are only defined on ints.
+// Right shift is arithmetic.
+func (i *Interpreter) bitwise(operator *ls.Token, left, right *parser.Value) *parser.Value {
+	i.checkIntOperands(operator, left, right)
+	a, b := *left.IntVal, *right.IntVal
+	switch operator.Type {
+	case ls.AMPERSAND:
+		return parser.NewIntValue(a & b)
+	case ls.PIPE:
+		return parser.NewIntValue(a | b)
+	case ls.CARET:
+		return parser.NewIntValue(a ^ b)
+	}
+	if b < 0 {
+		panic(newRuntimeError(operator, "Negative shift count."))
+	}
+	if operator.Type == ls.LESS_LESS {
+		return parser.NewIntValue(a << b)
+	}
+	return parser.NewIntValue(a >> b)
+}
+
+func (i *Interpreter) greater(operator *ls.Token, left, right *parser.Value) *parser.Value {
+	i.checkNumberOperands(operator, left, right)
+	c, ok := i.compareNumbers(left, right)
+	return parser.NewBoolValue(ok && c > 0)
+}
+
+func (i *Interpreter) greaterEqual(operator *ls.Token, left, right *parser.Value) *parser.Value {
+	i.checkNumberOperands(operator, left, right)
+	c, ok := i.compareNumbers(left, right)
+	return parser.NewBoolValue(ok && c >= 0)
+}
+
+func (i *Interpreter) less(operator *ls.Token, left, right *parser.Value) *parser.Value {
+	i.checkNumberOperands(operator, left, right)
+	c, ok := i.compareNumbers(left, right)
+	return parser.NewBoolValue(ok && c < 0)
+}
+
+func (i *Interpreter) lessEqual(operator *ls.Token, left, right *parser.Value) *parser.Value {
+	i.checkNumberOperands(operator, left, right)
+	c, ok := i.compareNumbers(left, right)
+	return parser.NewBoolValue(ok && c <= 0)
 }
 
 func (i *Interpreter) equal(left, right *parser.Value) *parser.Value {
-	if left.IsNil() && right.IsNil() {
-		return parser.NewBoolValue(true)
-	}
-	if left.IsNil() {
-		return parser.NewBoolValue(false)
-	}
-	return parser.NewBoolValue(left.String() == right.String())
+	return parser.NewBoolValue(left.Equals(right))
 }
 
 func (i *Interpreter) notEqual(left, right *parser.Value) *parser.Value {
 	return parser.NewBoolValue(!i.equal(left, right).IsTruthy())
 }
 
-func (i *Interpreter) negate(value *parser.Value) *parser.Value {
-	i.checkNumberOperand(value)
+func (i *Interpreter) negate(operator *ls.Token, value *parser.Value) *parser.Value {
+	i.checkNumberOperand(operator, value)
+	if value.IsInt() {
+		return parser.NewIntValue(-*value.IntVal)
+	}
 	return parser.NewFloatValue(-value.ToFloat64())
 }
 
+func (i *Interpreter) bitwiseNot(operator *ls.Token, value *parser.Value) *parser.Value {
+	if !value.IsInt() {
+		panic(newRuntimeError(operator, "Operand must be an integer"))
+	}
+	return parser.NewIntValue(^*value.IntVal)
+}
+
 func (i *Interpreter) logicalNot(value *parser.Value) *parser.Value {
 	return parser.NewBoolValue(!value.IsTruthy())
 }
 
 // Helper methods
-func (i *Interpreter) checkNumberOperand(value *parser.Value) {
+
+// arithmetic applies intOp to two int operands and floatOp otherwise.
+func (i *Interpreter) arithmetic(left, right *parser.Value, intOp func(a, b int) int, floatOp func(a, b float64) float64) *parser.Value {
+	if left.IsInt() && right.IsInt() {
+		return parser.NewIntValue(intOp(*left.IntVal, *right.IntVal))
+	}
+	return parser.NewFloatValue(floatOp(left.ToFloat64(), right.ToFloat64()))
+}
+
+// compareNumbers returns -1, 0 or 1, or false if the operands are
+// unordered because one is NaN. Ints are compared with floats exactly
+// rather than through float64, which can't represent every int, so the
+// ordering agrees with ==.
+func (i *Interpreter) compareNumbers(left, right *parser.Value) (int, bool) {
+	switch {
+	case left.IsInt() && right.IsInt():
+		return cmp.Compare(*left.IntVal, *right.IntVal), true
+	case left.IsInt():
+		return compareIntFloat(*left.IntVal, *right.FloatVal)
+	case right.IsInt():
+		c, ok := compareIntFloat(*right.IntVal, *left.FloatVal)
+		return -c, ok
+	}
+	a, b := *left.FloatVal, *right.FloatVal
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return 0, false
+	}
+	return cmp.Compare(a, b), true
+}
+
+// compareIntFloat compares n with f exactly. A float with no exact int
+// value lies strictly between two ints, or beyond the range of int.
+func compareIntFloat(n int, f float64) (int, bool) {
+	if math.IsNaN(f) {
+		return 0, false
+	}
+	if m, ok := parser.ExactInt(f); ok {
+		return cmp.Compare(n, m), true
+	}
+	switch {
+	case f >= math.MaxInt:
+		return -1, true
+	case f < math.MinInt:
+		return 1, true
+	case n <= int(math.Floor(f)):
+		return -1, true
+	}
+	return 1, true
+}
+
+func (i *Interpreter) lookUpVariable(name *ls.Token, expr parser.Expr) *parser.Value {
+	if distance, ok := i.locals[expr]; ok {
+		return i.environment.GetAt(distance, name.Lexeme)
+	}
+	return i.globals.Get(name)
+}
+
+// call invokes callee after checking that it is callable with the given
+// number of arguments. Natives taking callbacks go through it too.
+func (i *Interpreter) call(paren *ls.Token, callee *parser.Value, arguments []*parser.Value) *parser.Value {
+	if !callee.IsCallable() {
+		panic(newRuntimeError(paren, "Can only call functions and classes."))
+	}
+	function := callee.CallableVal
+	if len(arguments) != function.Arity() {
+		panic(newRuntimeError(paren,
+			fmt.Sprintf("Expected %d arguments but got %d.", function.Arity(), len(arguments))))
+	}
+	return function.Call(paren, arguments)
+}
+
+// assignVariable stores value in the variable expr refers to, using the
+// resolver's binding as lookUpVariable does.
+func (i *Interpreter) assignVariable(name *ls.Token, expr parser.Expr, value *parser.Value) {
+	if distance, ok := i.locals[expr]; ok {
+		i.environment.AssignAt(distance, name, value)
+	} else {
+		i.globals.Assign(name, value)
+	}
+}
+
+func (i *Interpreter) checkNumberOperand(operator *ls.Token, value *parser.Value) {
 	if !value.IsNumber() {
-		panic("Operand must be a number")
+		panic(newRuntimeError(operator, "Operand must be a number"))
 	}
 }
 
-func (i *Interpreter) checkNumberOperands(left, right *parser.Value) {
+func (i *Interpreter) checkNumberOperands(operator *ls.Token, left, right *parser.Value) {
 	if !left.IsNumber() || !right.IsNumber() {
-		panic("Operands must be numbers")
+		panic(newRuntimeError(operator, "Operands must be numbers"))
+	}
+}
+
+func (i *Interpreter) checkIntOperands(operator *ls.Token, left, right *parser.Value) {
+	if !left.IsInt() || !right.IsInt() {
+		panic(newRuntimeError(operator, "Operands must be integers"))
 	}
 }
 
-// Main interpret method
-func (i *Interpreter) Interpret(statements []parser.Stmt) {
+// Main interpret method. A RuntimeError stops execution and is returned;
+// any other panic is a bug in the interpreter and is left to propagate.
+func (i *Interpreter) Interpret(statements []parser.Stmt) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch signal := r.(type) {
+			case RuntimeError:
+				err = signal
+			case returnSignal:
+				err = newRuntimeError(signal.keyword, "Can't return from top-level code.")
+			default:
+				panic(r)
+			}
+		}
+	}()
+
 	for _, stmt := range statements {
 		result := stmt.Accept(i)
 		if i.mode == ModePrompt && stmt.Type() == parser.EXPRESSION_STMT {
@@ -213,4 +688,5 @@ func (i *Interpreter) Interpret(statements []parser.Stmt) {
 			}
 		}
 	}
+	return nil
 }
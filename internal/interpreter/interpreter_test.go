@@ -0,0 +1,452 @@
+package interpreter_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/interpreter"
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	"github.com/Piyush01Bhatt/interpreter_go/internal/resolver"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+// compile scans, parses and resolves source for interp, failing the test
+// on any static error.
+func compile(t *testing.T, interp *interpreter.Interpreter, source string) []parser.Stmt {
+	t.Helper()
+	tokens, scanErrors := ls.NewLexScanner(source).ScanTokens()
+	if len(scanErrors) > 0 {
+		t.Fatalf("%q: unexpected scan errors %v", source, scanErrors)
+	}
+	statements, parseErrors := parser.NewParser(tokens).Parse()
+	if len(parseErrors) > 0 {
+		t.Fatalf("%q: unexpected parse errors %v", source, parseErrors)
+	}
+	if resolveErrors := resolver.NewResolver(interp).Resolve(statements); len(resolveErrors) > 0 {
+		t.Fatalf("%q: unexpected resolve errors %v", source, resolveErrors)
+	}
+	return statements
+}
+
+// captureStdout returns what fn prints to standard output.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+	fn()
+	w.Close()
+	return <-output
+}
+
+// run interprets source as a script, returning what it printed and the
+// runtime error that stopped it, if any.
+func run(t *testing.T, source string) (string, error) {
+	t.Helper()
+	interp := interpreter.NewInterpreter(interpreter.ModeFile)
+	statements := compile(t, interp, source)
+	var err error
+	output := captureStdout(t, func() {
+		err = interp.Interpret(statements)
+	})
+	return output, err
+}
+
+// runTest is a script, the lines it prints and the runtime error it ends
+// with, if wantErr is set.
+type runTest struct {
+	source  string
+	want    []string
+	wantErr string
+}
+
+func runTests(t *testing.T, tests []runTest) {
+	t.Helper()
+	for _, test := range tests {
+		output, err := run(t, test.source)
+		want := ""
+		if len(test.want) > 0 {
+			want = strings.Join(test.want, "\n") + "\n"
+		}
+		if output != want {
+			t.Errorf("%q: printed %q, want %q", test.source, output, want)
+		}
+		switch {
+		case test.wantErr == "" && err != nil:
+			t.Errorf("%q: unexpected error %v", test.source, err)
+		case test.wantErr != "" && (err == nil || err.Error() != test.wantErr):
+			t.Errorf("%q: got error %v, want %q", test.source, err, test.wantErr)
+		}
+	}
+}
+
+func TestRuntimeErrors(t *testing.T) {
+	runTests(t, []runTest{
+		{`print 1 + "a";`, nil, "[line 1] Operands must be two numbers or two strings"},
+		{`print -"a";`, nil, "[line 1] Operand must be a number"},
+		{"print 1;\nprint missing;\nprint 2;", []string{"1"}, "[line 2] Undefined variable 'missing'."},
+		{"missing = 1;", nil, "[line 1] Undefined variable 'missing'."},
+	})
+}
+
+func TestBlockScopes(t *testing.T) {
+	runTests(t, []runTest{
+		{"var a = 1; { var a = 2; print a; } print a;", []string{"2", "1"}, ""},
+		{"var a = 1; { a = 2; } print a;", []string{"2"}, ""},
+		{"{ var a = 1; { var b = a + 1; print b; } }", []string{"2"}, ""},
+		{"{ var a = 1; } print a;", nil, "[line 1] Undefined variable 'a'."},
+	})
+}
+
+func TestConditionals(t *testing.T) {
+	runTests(t, []runTest{
+		{"if (1 < 2) print 1; else print 2;", []string{"1"}, ""},
+		{"if (nil) print 1; else print 2;", []string{"2"}, ""},
+		{"if (true) if (false) print 1; else print 2;", []string{"2"}, ""},
+		{`print nil or "default";`, []string{`"default"`}, ""},
+		{"print false and missing;", []string{"false"}, ""},
+		{"print 1 or missing;", []string{"1"}, ""},
+	})
+}
+
+func TestLoops(t *testing.T) {
+	runTests(t, []runTest{
+		{"var i = 0; while (i < 3) { print i; i = i + 1; }", []string{"0", "1", "2"}, ""},
+		{"for (var i = 0; i < 3; i = i + 1) print i;", []string{"0", "1", "2"}, ""},
+		{"var i = 5; for (; i < 7;) i = i + 1; print i;", []string{"7"}, ""},
+		{"for (var i = 0; i < 1; i = i + 1) {} print i;", nil, "[line 1] Undefined variable 'i'."},
+		{"while (false) print 1;", nil, ""},
+	})
+}
+
+func TestFunctions(t *testing.T) {
+	runTests(t, []runTest{
+		{"fun add(a, b) { return a + b; } print add(1, 2);", []string{"3"}, ""},
+		{"fun f() {} print f();", []string{"nil"}, ""},
+		{"fun fib(n) { if (n < 2) return n; return fib(n - 1) + fib(n - 2); } print fib(10);", []string{"55"}, ""},
+		{"fun counter() { var n = 0; fun inc() { n = n + 1; return n; } return inc; }\n" +
+			"var c = counter(); c(); print c();", []string{"2"}, ""},
+		{`print clock() > 0;`, []string{"true"}, ""},
+		{"fun f(a) {} f(1, 2);", nil, "[line 1] Expected 1 arguments but got 2."},
+		{`"f"();`, nil, "[line 1] Can only call functions and classes."},
+		{"fun f() { f(); } f();", nil, "[line 1] Stack overflow."},
+	})
+}
+
+func TestResolvedBindings(t *testing.T) {
+	runTests(t, []runTest{
+		{`var a = "global"; { fun show() { print a; } show(); var a = "block"; show(); }`,
+			[]string{`"global"`, `"global"`}, ""},
+		{"fun f() { var x = 1; fun g() { return x; } x = 2; return g; } print f()();", []string{"2"}, ""},
+	})
+}
+
+func TestClasses(t *testing.T) {
+	runTests(t, []runTest{
+		{"class Point { init(x, y) { this.x = x; this.y = y; } sum() { return this.x + this.y; } }\n" +
+			"print Point(1, 2).sum();", []string{"3"}, ""},
+		{"class C {} var c = C(); c.f = 1; c.f = c.f + 1; print c.f;", []string{"2"}, ""},
+		{"class C { m() { return this; } } var c = C(); print c.m() == c;", []string{"true"}, ""},
+		{"class C { init() { this.n = 1; } } var c = C(); print c.init().n;", []string{"1"}, ""},
+		{"class C { greet() { print 1; } } var g = C().greet; g();", []string{"1"}, ""},
+		{"class C {} C().missing;", nil, "[line 1] Undefined property 'missing'."},
+		{"var x = 1; x.f;", nil, "[line 1] Only instances have properties."},
+		{"var x = 1; x.f = 2;", nil, "[line 1] Only instances have fields."},
+	})
+}
+
+func TestInheritance(t *testing.T) {
+	runTests(t, []runTest{
+		{"class A { m() { return 1; } } class B < A {} print B().m();", []string{"1"}, ""},
+		{"class A { m() { return 1; } } class B < A { m() { return super.m() + 1; } } print B().m();", []string{"2"}, ""},
+		{"class A { init(x) { this.x = x; } } class B < A { init() { super.init(3); } } print B().x;", []string{"3"}, ""},
+		{"class A {} class B < A { m() { return super.missing(); } } B().m();", nil, "[line 1] Undefined property 'missing'."},
+		{"var A = 1; class B < A {}", nil, "[line 1] Superclass must be a class."},
+	})
+}
+
+func TestIntegers(t *testing.T) {
+	runTests(t, []runTest{
+		{"print 2 * 3;", []string{"6"}, ""},
+		{"print 7 / 2;", []string{"3.5"}, ""},
+		{"print 1 + 0.5;", []string{"1.5"}, ""},
+		{"print 9223372036854775807 + 1;", []string{"-9223372036854775808"}, ""},
+		{"print 1 == 1.0;", []string{"true"}, ""},
+		{"print 9007199254740993 == 9007199254740992.0;", []string{"false"}, ""},
+	})
+}
+
+func TestMixedComparisons(t *testing.T) {
+	runTests(t, []runTest{
+		{"print 9007199254740993 <= 9007199254740992.0;", []string{"false"}, ""},
+		{"print 9007199254740993 >= 9007199254740992.0;", []string{"true"}, ""},
+		{"print 9007199254740992.0 < 9007199254740993;", []string{"true"}, ""},
+		{"print 2 < 2.5; print -3 < -2.5; print 3 > 2.5;", []string{"true", "true", "true"}, ""},
+		{"print 1 <= 1.0; print 1.0 >= 1;", []string{"true", "true"}, ""},
+		{"print 9223372036854775807 < 9223372036854775807.0;", []string{"true"}, ""},
+		{"print -9223372036854775807 - 1 <= -9223372036854775808.0;", []string{"true"}, ""},
+		{"var nan = 0.0 / 0.0; print nan < 1; print nan >= 1; print 1 <= nan; print nan == nan;",
+			[]string{"false", "false", "false", "false"}, ""},
+	})
+}
+
+func TestArithmeticOperators(t *testing.T) {
+	runTests(t, []runTest{
+		{"print 7 % 3; print -7 % 3; print 7.5 % 2;", []string{"1", "2", "1.5"}, ""},
+		{"print 7 ~/ 2; print -7 ~/ 2;", []string{"3", "-4"}, ""},
+		{"print 2 ** 10; print 2 ** -1; print -2 ** 2;", []string{"1024", "0.5", "-4"}, ""},
+		{"print 6 & 3; print 6 | 3; print 6 ^ 3; print ~5;", []string{"2", "7", "5", "-6"}, ""},
+		{"print 1 << 4; print -16 >> 2;", []string{"16", "-4"}, ""},
+		{"print 1 % 0;", nil, "[line 1] Division by zero."},
+		{"print 1 ~/ 0;", nil, "[line 1] Division by zero."},
+		{"print 1 << -1;", nil, "[line 1] Negative shift count."},
+		{"print 1.5 & 1;", nil, "[line 1] Operands must be integers"},
+	})
+}
+
+func TestAssignmentOperators(t *testing.T) {
+	runTests(t, []runTest{
+		{"var i = 1; i += 2; print i; i -= 1; print i; i *= 3; print i; i /= 4; print i;",
+			[]string{"3", "2", "6", "1.5"}, ""},
+		{"var k = 7; k %= 4; print k;", []string{"3"}, ""},
+		{`var s = "a"; s += "b"; print s;`, []string{`"ab"`}, ""},
+		{"var j = 5; print j++; print j; print ++j; print j--; print --j;", []string{"5", "6", "7", "7", "5"}, ""},
+		{"class C {} var c = C(); c.n = 1; c.n += 1; print c.n++; print c.n;", []string{"2", "3"}, ""},
+		{"print true ? 1 : 2; print nil ? 1 : false ? 2 : 3;", []string{"1", "3"}, ""},
+		{"print true ? 1 : missing;", []string{"1"}, ""},
+		{`var s = "a"; s++;`, nil, "[line 1] Operand must be a number"},
+	})
+}
+
+func TestLists(t *testing.T) {
+	runTests(t, []runTest{
+		{"var xs = [1, 2, 3]; print xs; print xs[0]; print xs[-1]; print len(xs);", []string{"[1, 2, 3]", "1", "3", "3"}, ""},
+		{"var xs = [1, 2, 3]; xs[1] = 5; print xs;", []string{"[1, 5, 3]"}, ""},
+		{"var xs = [1, 2, 3]; print xs[1:]; print xs[:2];", []string{"[2, 3]", "[1, 2]"}, ""},
+		{`print "hello"[1:3]; print "abc"[1];`, []string{`"el"`, `"b"`}, ""},
+		{"var xs = [1]; append(xs, xs); print xs;", []string{"[1, [...]]"}, ""},
+		{"print [1, [2]] == [1, [2]]; print [1] == [2];", []string{"true", "false"}, ""},
+		{"var xs = [1]; print xs[5];", nil, "[line 1] Index out of range."},
+		{`var xs = [1]; print xs["a"];`, nil, "[line 1] Index must be an integer."},
+		{"var n = 1; print n[0];", nil, "[line 1] Only maps, lists and strings can be indexed."},
+	})
+}
+
+func TestMaps(t *testing.T) {
+	runTests(t, []runTest{
+		{`var m = {"a": 1, 2: "b"}; print m; print m["a"]; print m[2.0];`, []string{`{"a": 1, 2: "b"}`, "1", `"b"`}, ""},
+		{`var m = {"a": 1}; m["c"] = 3; print len(m); print keys(m); print values(m);`,
+			[]string{"2", `["a", "c"]`, "[1, 3]"}, ""},
+		{"print {1: 2} == {1.0: 2}; print {};", []string{"true", "{}"}, ""},
+		{`var m = {}; print m["x"];`, nil, `[line 1] Undefined key "x".`},
+		{"var m = {[1]: 2};", nil, "[line 1] Unhashable map key of type list."},
+	})
+}
+
+func TestLambdas(t *testing.T) {
+	runTests(t, []runTest{
+		{"var add = fun (a, b) { return a + b; }; print add(1, 2);", []string{"3"}, ""},
+		{"var sq = x => x * x; print sq(4);", []string{"16"}, ""},
+		{"print ((a, b) => a - b)(5, 2); print (() => 7)();", []string{"3", "7"}, ""},
+		{"var f = x => { return x + 1; }; print f(1);", []string{"2"}, ""},
+		{"fun adder(n) { return x => x + n; } print adder(2)(3);", []string{"5"}, ""},
+	})
+}
+
+func TestHigherOrderNatives(t *testing.T) {
+	runTests(t, []runTest{
+		{"print map([1, 2, 3], x => x * 2);", []string{"[2, 4, 6]"}, ""},
+		{"print filter([1, 2, 3, 4], x => x % 2 == 0);", []string{"[2, 4]"}, ""},
+		{"var xs = [3, 1, 2]; print sort(xs, (a, b) => a < b); print xs;", []string{"[1, 2, 3]", "[3, 1, 2]"}, ""},
+		{"print filter(0..6, x => x % 3 == 0);", []string{"[0, 3]"}, ""},
+		{`print map("ab", c => c + c);`, []string{`["aa", "bb"]`}, ""},
+		{`print map({"b": 1, "a": 2}, k => k);`, []string{`["b", "a"]`}, ""},
+		{"class Count { init() { this.n = 0; } hasNext() { return this.n < 3; } next() { this.n += 1; return this.n; } }\n" +
+			"print sort(Count(), (a, b) => a > b);", []string{"[3, 2, 1]"}, ""},
+		{"map(1, x => x);", nil, "[line 1] Can only iterate over lists, strings, maps, ranges, enums and iterable objects."},
+		{"map([1], 2);", nil, "[line 1] Can only call functions and classes."},
+	})
+}
+
+func TestForIn(t *testing.T) {
+	runTests(t, []runTest{
+		{"for (x in [1, 2]) print x;", []string{"1", "2"}, ""},
+		{`for (c in "hé") print c;`, []string{`"h"`, `"é"`}, ""},
+		{"for (i in 0..3) print i; print 0..3;", []string{"0", "1", "2", "0..3"}, ""},
+		{`for (k in {"a": 1, "b": 2}) print k;`, []string{`"a"`, `"b"`}, ""},
+		{"class It { init() { this.n = 0; } iterator() { return this; }\n" +
+			"hasNext() { return this.n < 2; } next() { this.n += 1; return this.n; } }\n" +
+			"for (v in It()) print v;", []string{"1", "2"}, ""},
+		{"var xs = [1]; for (x in xs) { if (x < 3) append(xs, x + 1); } print xs;", []string{"[1, 2, 3]"}, ""},
+		{`print 2 in 0..3; print 3 in 0..3; print "b" in "abc"; print 2 in [1, 2];`,
+			[]string{"true", "false", "true", "true"}, ""},
+		{"for (x in 1) print x;", nil, "[line 1] Can only iterate over lists, strings, maps, ranges, enums and iterable objects."},
+		{"print 0..1.5;", nil, "[line 1] Range bounds must be integers."},
+	})
+}
+
+func TestTryCatchFinally(t *testing.T) {
+	runTests(t, []runTest{
+		{`try { throw "boom"; } catch (e) { print e; }`, []string{`"boom"`}, ""},
+		{"try { var x = [1][3]; } catch (e) { print e.message; print e.line; }", []string{`"Index out of range."`, "1"}, ""},
+		{`fun f() { try { return 1; } finally { print "fin"; } } print f();`, []string{`"fin"`, "1"}, ""},
+		{`try { try { throw 1; } finally { print "inner"; } } catch (e) { print e; }`, []string{`"inner"`, "1"}, ""},
+		{`try { throw 1; } catch (e) { throw e + 1; } finally { print "cleanup"; }`, []string{`"cleanup"`}, "[line 1] Uncaught 2."},
+		{`throw "x";`, nil, `[line 1] Uncaught "x".`},
+	})
+}
+
+func TestMatch(t *testing.T) {
+	const describe = "fun d(v) { match (v) {\n" +
+		`case 0 | 1 => print "small";` + "\n" +
+		"case [a, b] => print a + b;\n" +
+		"case [_, _, c] if c > 2 => print c;\n" +
+		`case n if n > 10 => print "big";` + "\n" +
+		`case _ => print "other";` + "\n" +
+		"} }\n"
+	runTests(t, []runTest{
+		{describe + "d(1); d([1, 2]); d([1, 2, 3]); d(11); d(5);",
+			[]string{`"small"`, "3", "3", `"big"`, `"other"`}, ""},
+		{`match ("x") { case "x" => print "str"; } match (nil) { case nil => print "nil"; }`,
+			[]string{`"str"`, `"nil"`}, ""},
+		{"var xs = [1, 5]; match (xs) { case [a, b] if len(filter(xs, x => x > 1)) > 0 => print b; }", []string{"5"}, ""},
+		{"match (-2) { case -2 => print 1; }", []string{"1"}, ""},
+		{"match (3) { case 1 => print 1; }", nil, "[line 1] No match arm matched 3."},
+	})
+}
+
+func TestConst(t *testing.T) {
+	runTests(t, []runTest{
+		{"const A = 1; print A;", []string{"1"}, ""},
+		{"const B = [1]; B[0] = 2; print B;", []string{"[2]"}, ""},
+		{"fun f() { const C = 3; return C; } print f();", []string{"3"}, ""},
+		{"const A = 1; { var A = 2; A = 3; print A; } print A;", []string{"3", "1"}, ""},
+	})
+}
+
+func TestDestructuring(t *testing.T) {
+	runTests(t, []runTest{
+		{"var [a, b] = [1, 2]; print a; print b; [a, b] = [b, a]; print a; print b;", []string{"1", "2", "2", "1"}, ""},
+		{`var {x, y} = {"x": 1, "y": 2}; print x + y;`, []string{"3"}, ""},
+		{"class P { init() { this.x = 3; this.y = 4; } } { var {x, y} = P(); print x * y; }", []string{"12"}, ""},
+		{"const [c, d] = [5, 6]; print c + d;", []string{"11"}, ""},
+		{"var [e] = [1, 2];", nil, "[line 1] Expected a list of 1 elements to destructure but got 2."},
+		{"var [q] = 1;", nil, "[line 1] Can only destructure a list with '[...]', got int."},
+		{"var {q} = {};", nil, "[line 1] Map has no key 'q' to destructure."},
+	})
+}
+
+// moduleTest is a set of files written to a temporary directory, the lines
+// running main.lox prints and the runtime error it ends with, in which the
+// directory is written as "DIR".
+type moduleTest struct {
+	files   map[string]string
+	want    []string
+	wantErr string
+}
+
+func runModuleTests(t *testing.T, tests []moduleTest) {
+	t.Helper()
+	for idx, test := range tests {
+		dir := t.TempDir()
+		for name, source := range test.files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(source), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		mainPath := filepath.Join(dir, "main.lox")
+		interp := interpreter.NewInterpreter(interpreter.ModeFile)
+		err := interp.ConfigureModules(func(source string) ([]parser.Stmt, error) {
+			return compile(t, interp, source), nil
+		}, mainPath, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		statements := compile(t, interp, test.files["main.lox"])
+		output := captureStdout(t, func() {
+			err = interp.Interpret(statements)
+		})
+
+		want := ""
+		if len(test.want) > 0 {
+			want = strings.Join(test.want, "\n") + "\n"
+		}
+		if output != want {
+			t.Errorf("test %d: printed %q, want %q", idx, output, want)
+		}
+		gotErr := ""
+		if err != nil {
+			gotErr = strings.ReplaceAll(err.Error(), dir, "DIR")
+		}
+		if gotErr != test.wantErr {
+			t.Errorf("test %d: got error %q, want %q", idx, gotErr, test.wantErr)
+		}
+	}
+}
+
+func TestModules(t *testing.T) {
+	runTests(t, []runTest{
+		{`import "a.lox" as a;`, nil, "[line 1] Imports are not enabled."},
+	})
+	runModuleTests(t, []moduleTest{
+		{map[string]string{
+			"main.lox": `import "math.lox" as m; print m.square(3); print m.PI;`,
+			"math.lox": "export fun square(x) { return x * x; } export const PI = 3; var hidden = 1;",
+		}, []string{"9", "3"}, ""},
+		{map[string]string{
+			"main.lox": `import "a.lox" as a; import "a.lox" as b; print a == b;`,
+			"a.lox":    `print "loaded";`,
+		}, []string{`"loaded"`, "true"}, ""},
+		{map[string]string{
+			"main.lox": `import "a.lox" as a; print a.hidden;`,
+			"a.lox":    "var hidden = 1;",
+		}, nil, "[line 1] Module 'a.lox' does not export 'hidden'."},
+		{map[string]string{
+			"main.lox": `import "a.lox" as a; a.x = 2;`,
+			"a.lox":    "export var x = 1;",
+		}, nil, "[line 1] Can't assign to members of module 'a.lox'."},
+		{map[string]string{
+			"main.lox": `import "a.lox" as a;`,
+			"a.lox":    `import "b.lox" as b;`,
+			"b.lox":    `import "a.lox" as a;`,
+		}, nil, "[line 1] Import cycle: DIR/a.lox -> DIR/b.lox -> DIR/a.lox."},
+		{map[string]string{
+			"main.lox": `import "a.lox" as a;`,
+			"a.lox":    `import "main.lox" as m;`,
+		}, nil, "[line 1] Import cycle: DIR/main.lox -> DIR/a.lox -> DIR/main.lox."},
+		{map[string]string{
+			"main.lox": `import "missing.lox" as m;`,
+		}, nil, "[line 1] Can't find module 'missing.lox'."},
+	})
+}
+
+func TestEnums(t *testing.T) {
+	const color = "enum Color { Red, Green }\n"
+	runTests(t, []runTest{
+		{color + "print Color.Red; print Color.Green.name; print Color.Green.ordinal; print Color;",
+			[]string{"Color.Red", `"Green"`, "1", "<enum Color>"}, ""},
+		{color + "print Color.Red == Color.Red; print Color.Red == Color.Green;", []string{"true", "false"}, ""},
+		{color + "for (c in Color) print c;", []string{"Color.Red", "Color.Green"}, ""},
+		{color + `var c = Color.Green; match (c) { case Color.Red => print "r"; case Color.Green => print "g"; }`,
+			[]string{`"g"`}, ""},
+		{color + "var m = {Color.Red: 1}; print m[Color.Red];", []string{"1"}, ""},
+		{color + "var alias = Color; print alias.Gren;", nil, "[line 2] Enum 'Color' has no member 'Gren'."},
+		{color + "Color.Blue = 1;", nil, "[line 2] Can't add members to enum 'Color'."},
+		{color + `Color.Red.name = "x";`, nil, "[line 2] Can't set properties on enum members."},
+	})
+}
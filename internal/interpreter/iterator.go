@@ -0,0 +1,129 @@
+package interpreter
+
+import (
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+// Iterator yields the elements of an iterable value one at a time. Next
+// reports false once the elements are exhausted.
+type Iterator interface {
+	Next() (*parser.Value, bool)
+}
+
+type listIterator struct {
+	list *parser.List
+	next int
+}
+
+// Next re-reads the list each step, so elements appended during the loop
+// are visited too.
+func (it *listIterator) Next() (*parser.Value, bool) {
+	if it.next >= len(it.list.Elements) {
+		return nil, false
+	}
+	value := it.list.Elements[it.next]
+	it.next++
+	return value, true
+}
+
+type sliceIterator struct {
+	values []*parser.Value
+	next   int
+}
+
+func (it *sliceIterator) Next() (*parser.Value, bool) {
+	if it.next >= len(it.values) {
+		return nil, false
+	}
+	value := it.values[it.next]
+	it.next++
+	return value, true
+}
+
+type rangeIterator struct {
+	next, end int
+}
+
+func (it *rangeIterator) Next() (*parser.Value, bool) {
+	if it.next >= it.end {
+		return nil, false
+	}
+	value := parser.NewIntValue(it.next)
+	it.next++
+	return value, true
+}
+
+// objectIterator drives a user-defined iterator through its hasNext() and
+// next() methods.
+type objectIterator struct {
+	interpreter *Interpreter
+	keyword     *ls.Token
+	object      parser.Object
+}
+
+func (it *objectIterator) Next() (*parser.Value, bool) {
+	if !it.invoke("hasNext").IsTruthy() {
+		return nil, false
+	}
+	return it.invoke("next"), true
+}
+
+func (it *objectIterator) invoke(method string) *parser.Value {
+	name := propertyToken(it.keyword, method)
+	return it.interpreter.call(name, it.object.Get(name), nil)
+}
+
+// iterate returns an Iterator over value. Strings yield their characters,
+// maps their keys in insertion order, ranges their ints and enums their
+// members. An instance is iterable if it has an iterator() method
+// returning an object with hasNext() and next(), or if it has hasNext()
+// and next() itself.
+func (i *Interpreter) iterate(keyword *ls.Token, value *parser.Value) Iterator {
+	switch {
+	case value.IsList():
+		return &listIterator{list: value.ListVal}
+	case value.IsString():
+		runes := []rune(*value.StrVal)
+		chars := make([]*parser.Value, len(runes))
+		for idx, r := range runes {
+			chars[idx] = parser.NewStringValue(string(r))
+		}
+		return &sliceIterator{values: chars}
+	case value.IsMap():
+		return &sliceIterator{values: value.MapVal.Keys()}
+	case value.IsRange():
+		return &rangeIterator{next: value.RangeVal.Start, end: value.RangeVal.End}
+	case value.IsObject():
+		if enum, ok := value.ObjectVal.(*Enum); ok {
+			members := make([]*parser.Value, len(enum.members))
+			for idx, member := range enum.members {
+				members[idx] = parser.NewObjectValue(member)
+			}
+			return &sliceIterator{values: members}
+		}
+		instance, ok := value.ObjectVal.(*Instance)
+		if ok && instance.Has("iterator") {
+			name := propertyToken(keyword, "iterator")
+			iterator := i.call(name, instance.Get(name), nil)
+			if !iterator.IsObject() {
+				panic(newRuntimeError(keyword, "iterator() must return an object."))
+			}
+			return &objectIterator{interpreter: i, keyword: keyword, object: iterator.ObjectVal}
+		}
+		if ok && instance.Has("hasNext") && instance.Has("next") {
+			return &objectIterator{interpreter: i, keyword: keyword, object: instance}
+		}
+	}
+	panic(newRuntimeError(keyword, "Can only iterate over lists, strings, maps, ranges, enums and iterable objects."))
+}
+
+// propertyToken makes a synthetic identifier token for name, positioned at
+// at so runtime errors point back to the loop.
+func propertyToken(at *ls.Token, name string) *ls.Token {
+	token := *at
+	token.Type = ls.IDENTIFIER
+	token.Lexeme = name
+	token.Literal = nil
+	return &token
+}
@@ -0,0 +1,66 @@
+package interpreter
+
+import (
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+)
+
+func (i *Interpreter) VisitMatchStmt(stmt *parser.MatchStmt) *parser.Value {
+	subject := stmt.Subject.Accept(i)
+	for _, arm := range stmt.Arms {
+		env := NewEnclosedEnv(i.environment)
+		if !i.matchPattern(arm.Pattern, subject, env) {
+			continue
+		}
+		if arm.Guard != nil && !i.evaluateIn(arm.Guard, env).IsTruthy() {
+			continue
+		}
+		i.executeBlock([]parser.Stmt{arm.Body}, env)
+		return nil
+	}
+	panic(newRuntimeError(stmt.Keyword, "No match arm matched "+subject.String()+"."))
+}
+
+// matchPattern reports whether value matches pattern, defining any names
+// the pattern binds in env. Bindings may be left behind by a failed match,
+// so env must be discarded in that case.
+func (i *Interpreter) matchPattern(pattern parser.Pattern, value *parser.Value, env *Env) bool {
+	switch pattern := pattern.(type) {
+	case *parser.WildcardPattern:
+		return true
+	case *parser.BindingPattern:
+		env.Define(pattern.Name, value)
+		return true
+	case *parser.LiteralPattern:
+		return pattern.Value.Equals(value)
+	case *parser.MemberPattern:
+		return i.evaluateIn(pattern.Value, env).Equals(value)
+	case *parser.ListPattern:
+		if !value.IsList() || len(value.ListVal.Elements) != len(pattern.Elements) {
+			return false
+		}
+		for idx, element := range pattern.Elements {
+			if !i.matchPattern(element, value.ListVal.Elements[idx], env) {
+				return false
+			}
+		}
+		return true
+	case *parser.AlternativePattern:
+		for _, alternative := range pattern.Alternatives {
+			if i.matchPattern(alternative, value, env) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// evaluateIn evaluates expr with env as the current environment.
+func (i *Interpreter) evaluateIn(expr parser.Expr, env *Env) *parser.Value {
+	previous := i.environment
+	defer func() {
+		i.environment = previous
+	}()
+
+	i.environment = env
+	return expr.Accept(i)
+}
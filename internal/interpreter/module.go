@@ -0,0 +1,172 @@
+package interpreter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+// ModuleCompiler scans, parses and resolves the source of an imported
+// module. The driver supplies it because resolving needs the resolver
+// package, which depends on this one.
+type ModuleCompiler func(source string) ([]parser.Stmt, error)
+
+// Module is the namespace an import binds. Only names declared with
+// export can be read from it, and they can't be assigned from outside.
+type Module struct {
+	name    string
+	dir     string
+	env     *Env
+	exports map[string]bool
+}
+
+func (m *Module) Get(name *ls.Token) *parser.Value {
+	if !m.exports[name.Lexeme] {
+		panic(newRuntimeError(name, "Module '"+m.name+"' does not export '"+name.Lexeme+"'."))
+	}
+	return m.env.Get(name)
+}
+
+func (m *Module) Set(name *ls.Token, value *parser.Value) {
+	panic(newRuntimeError(name, "Can't assign to members of module '"+m.name+"'."))
+}
+
+func (m *Module) String() string {
+	return fmt.Sprintf("<module %s>", m.name)
+}
+
+// moduleLoader locates imported files and keeps each module evaluated at
+// most once.
+type moduleLoader struct {
+	compile    ModuleCompiler
+	baseDir    string
+	searchPath []string
+	cache      map[string]*Module
+	loading    []string // absolute paths of modules being evaluated, outermost first
+	current    *Module  // nil while running the main script
+}
+
+// ConfigureModules enables imports for the main script at scriptPath,
+// or for REPL input when scriptPath is empty. A relative import path is
+// tried against the importing file's directory, the working directory
+// for the REPL, and then against each searchPath directory in order.
+func (i *Interpreter) ConfigureModules(compile ModuleCompiler, scriptPath string, searchPath []string) error {
+	i.modules.compile = compile
+	i.modules.searchPath = searchPath
+	if scriptPath == "" {
+		return nil
+	}
+
+	path, err := filepath.Abs(scriptPath)
+	if err != nil {
+		return err
+	}
+	i.modules.baseDir = filepath.Dir(path)
+	// The main script counts as being loaded, so that importing it back
+	// is reported as a cycle rather than running it again.
+	i.modules.loading = []string{path}
+	return nil
+}
+
+func (i *Interpreter) VisitImportStmt(stmt *parser.ImportStmt) *parser.Value {
+	module := i.importModule(stmt.Path)
+	i.environment.Define(stmt.Alias, parser.NewObjectValue(module))
+	return nil
+}
+
+func (i *Interpreter) VisitExportStmt(stmt *parser.ExportStmt) *parser.Value {
+	stmt.Declaration.Accept(i)
+	// Exports from the main script have no importer to see them.
+	if current := i.modules.current; current != nil {
+		for _, name := range parser.DeclaredNames(stmt.Declaration) {
+			current.exports[name.Lexeme] = true
+		}
+	}
+	return nil
+}
+
+// importModule returns the module at the path token names, evaluating it
+// on first import.
+func (i *Interpreter) importModule(pathToken *ls.Token) *Module {
+	loader := &i.modules
+	name := pathToken.Literal.(string)
+	if loader.compile == nil {
+		panic(newRuntimeError(pathToken, "Imports are not enabled."))
+	}
+
+	path, ok := loader.find(name)
+	if !ok {
+		panic(newRuntimeError(pathToken, "Can't find module '"+name+"'."))
+	}
+	if module, ok := loader.cache[path]; ok {
+		return module
+	}
+	if start := slices.Index(loader.loading, path); start >= 0 {
+		cycle := append(slices.Clone(loader.loading[start:]), path)
+		panic(newRuntimeError(pathToken, "Import cycle: "+strings.Join(cycle, " -> ")+"."))
+	}
+
+	source, err := os.ReadFile(path)
+	if err != nil {
+		panic(newRuntimeError(pathToken, "Can't read module '"+name+"': "+err.Error()+"."))
+	}
+	statements, err := loader.compile(string(source))
+	if err != nil {
+		panic(newRuntimeError(pathToken, "Module '"+name+"' has errors:\n"+err.Error()))
+	}
+
+	module := &Module{
+		name:    name,
+		dir:     filepath.Dir(path),
+		env:     NewEnclosedEnv(i.builtins),
+		exports: make(map[string]bool),
+	}
+	loader.loading = append(loader.loading, path)
+	defer func() {
+		loader.loading = loader.loading[:len(loader.loading)-1]
+	}()
+	i.runModule(module, statements)
+
+	loader.cache[path] = module
+	return module
+}
+
+// runModule executes statements with module's scope as the globals.
+func (i *Interpreter) runModule(module *Module, statements []parser.Stmt) {
+	previousGlobals, previousModule := i.globals, i.modules.current
+	defer func() {
+		i.globals, i.modules.current = previousGlobals, previousModule
+	}()
+
+	i.globals, i.modules.current = module.env, module
+	i.executeBlock(statements, module.env)
+}
+
+// find returns the absolute path of the file an import names.
+func (l *moduleLoader) find(name string) (string, bool) {
+	candidates := []string{name}
+	if !filepath.IsAbs(name) {
+		dir := l.baseDir
+		if l.current != nil {
+			dir = l.current.dir
+		}
+		candidates = []string{filepath.Join(dir, name)}
+		for _, root := range l.searchPath {
+			candidates = append(candidates, filepath.Join(root, name))
+		}
+	}
+
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			if path, err := filepath.Abs(candidate); err == nil {
+				return path, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,128 @@
+package interpreter
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+// defineNatives registers the built-in functions in the global scope.
+// map, filter and sort take anything a for-in loop can iterate over and
+// return a new list.
+func (i *Interpreter) defineNatives() {
+	i.DefineNative(&NativeFunction{
+		Name:   "clock",
+		Params: 0,
+		Fn: func(paren *ls.Token, arguments []*parser.Value) *parser.Value {
+			return parser.NewFloatValue(float64(time.Now().UnixNano()) / float64(time.Second))
+		},
+	})
+	i.DefineNative(&NativeFunction{
+		Name:   "len",
+		Params: 1,
+		Fn: func(paren *ls.Token, arguments []*parser.Value) *parser.Value {
+			switch value := arguments[0]; {
+			case value.IsMap():
+				return parser.NewIntValue(value.MapVal.Len())
+			case value.IsList():
+				return parser.NewIntValue(len(value.ListVal.Elements))
+			case value.IsString():
+				return parser.NewIntValue(len([]rune(*value.StrVal)))
+			case value.IsRange():
+				return parser.NewIntValue(value.RangeVal.Len())
+			}
+			panic(newRuntimeError(paren, "len() expects a map, list, string or range."))
+		},
+	})
+	i.DefineNative(&NativeFunction{
+		Name:   "append",
+		Params: 2,
+		Fn: func(paren *ls.Token, arguments []*parser.Value) *parser.Value {
+			list := arguments[0]
+			if !list.IsList() {
+				panic(newRuntimeError(paren, "append() expects a list."))
+			}
+			list.ListVal.Elements = append(list.ListVal.Elements, arguments[1])
+			return list
+		},
+	})
+	i.DefineNative(&NativeFunction{
+		Name:   "keys",
+		Params: 1,
+		Fn: func(paren *ls.Token, arguments []*parser.Value) *parser.Value {
+			if !arguments[0].IsMap() {
+				panic(newRuntimeError(paren, "keys() expects a map."))
+			}
+			return parser.NewListValue(arguments[0].MapVal.Keys())
+		},
+	})
+	i.DefineNative(&NativeFunction{
+		Name:   "values",
+		Params: 1,
+		Fn: func(paren *ls.Token, arguments []*parser.Value) *parser.Value {
+			if !arguments[0].IsMap() {
+				panic(newRuntimeError(paren, "values() expects a map."))
+			}
+			return parser.NewListValue(arguments[0].MapVal.Values())
+		},
+	})
+	i.DefineNative(&NativeFunction{
+		Name:   "map",
+		Params: 2,
+		Fn: func(paren *ls.Token, arguments []*parser.Value) *parser.Value {
+			elements := i.collect(paren, arguments[0])
+			mapped := make([]*parser.Value, len(elements))
+			for idx, element := range elements {
+				mapped[idx] = i.call(paren, arguments[1], []*parser.Value{element})
+			}
+			return parser.NewListValue(mapped)
+		},
+	})
+	i.DefineNative(&NativeFunction{
+		Name:   "filter",
+		Params: 2,
+		Fn: func(paren *ls.Token, arguments []*parser.Value) *parser.Value {
+			elements := i.collect(paren, arguments[0])
+			kept := make([]*parser.Value, 0, len(elements))
+			for _, element := range elements {
+				if i.call(paren, arguments[1], []*parser.Value{element}).IsTruthy() {
+					kept = append(kept, element)
+				}
+			}
+			return parser.NewListValue(kept)
+		},
+	})
+	i.DefineNative(&NativeFunction{
+		Name:   "sort",
+		Params: 2,
+		Fn: func(paren *ls.Token, arguments []*parser.Value) *parser.Value {
+			// less(a, b) must be truthy when a sorts before b. The sort is
+			// stable and returns a new list.
+			sorted := i.collect(paren, arguments[0])
+			sort.SliceStable(sorted, func(a, b int) bool {
+				return i.call(paren, arguments[1], []*parser.Value{sorted[a], sorted[b]}).IsTruthy()
+			})
+			return parser.NewListValue(sorted)
+		},
+	})
+}
+
+// collect returns the elements of any value a for-in loop can iterate
+// over, in a new slice, so later changes to a list don't affect them.
+func (i *Interpreter) collect(paren *ls.Token, value *parser.Value) []*parser.Value {
+	elements := make([]*parser.Value, 0)
+	for it := i.iterate(paren, value); ; {
+		element, ok := it.Next()
+		if !ok {
+			return elements
+		}
+		elements = append(elements, element)
+	}
+}
+
+// DefineNative makes a Go function callable from scripts under its name.
+func (i *Interpreter) DefineNative(native *NativeFunction) {
+	i.builtins.bind(native.Name, parser.NewCallableValue(native))
+}
@@ -2,7 +2,9 @@ package parser
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 
 	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
 )
@@ -15,14 +17,67 @@ const (
 	LITERAL
 	VARIABLE
 	ASSIGN
+	LOGICAL
+	CALL
+	GET
+	SET
+	THIS
+	SUPER
+	CONDITIONAL
+	UPDATE
+	LIST
+	INDEX
+	INDEX_SET
+	SLICE
+	MAP
+	LAMBDA
+	RANGE
+	DESTRUCTURE_ASSIGN
 )
 
+// Callable is a value that can be invoked with call syntax. Paren is the
+// closing parenthesis of the call, used to locate any error it raises.
+type Callable interface {
+	Arity() int
+	Call(paren *ls.Token, arguments []*Value) *Value
+	String() string
+}
+
+// Object is a value with properties reachable through dot syntax.
+// Property errors are raised with panic, located by name.
+type Object interface {
+	Get(name *ls.Token) *Value
+	Set(name *ls.Token, value *Value)
+	String() string
+}
+
+// List is a mutable sequence. Values holding the same *List share it.
+type List struct {
+	Elements []*Value
+}
+
+// Range is the half-open interval of ints [Start, End).
+type Range struct {
+	Start int
+	End   int
+}
+
+// Len returns the number of ints in the range.
+func (r *Range) Len() int {
+	return max(0, r.End-r.Start)
+}
+
 type Value struct {
-	StrVal   *string
-	IntVal   *int
-	FloatVal *float64
-	BoolVal  *bool
-	NilVal   *struct{}
+	StrVal      *string
+	IntVal      *int
+	FloatVal    *float64
+	BoolVal     *bool
+	NilVal      *struct{}
+	CallableVal Callable
+	ObjectVal   Object
+	ListVal     *List
+	MapVal      *Map
+	RangeVal    *Range
 }
 
 func NewStringValue(s string) *Value {
@@ -45,7 +100,34 @@ func NewNilValue() *Value {
 	return &Value{NilVal: &struct{}{}}
 }
 
+func NewCallableValue(c Callable) *Value {
+	return &Value{CallableVal: c}
+}
+
+func NewObjectValue(o Object) *Value {
+	return &Value{ObjectVal: o}
+}
+
+func NewListValue(elements []*Value) *Value {
+	return &Value{ListVal: &List{Elements: elements}}
+}
+
+func NewMapValue(m *Map) *Value {
+	return &Value{MapVal: m}
+}
+
+func NewRangeValue(start, end int) *Value {
+	return &Value{RangeVal: &Range{Start: start, End: end}}
+}
+
 func (v *Value) String() string {
+	return v.format(make(map[any]bool))
+}
+
+// format renders v, printing "[...]" or "{...}" for a list or map that
+// contains itself. active holds the containers being rendered on the
+// current path.
+func (v *Value) format(active map[any]bool) string {
 	switch {
 	case v.StrVal != nil:
 		return fmt.Sprintf("%q", *v.StrVal) // Quote strings
@@ -55,11 +137,129 @@ func (v *Value) String() string {
 		return fmt.Sprintf("%g", *v.FloatVal) // Avoid unnecessary trailing zeros
 	case v.BoolVal != nil:
 		return strconv.FormatBool(*v.BoolVal)
+	case v.CallableVal != nil:
+		return v.CallableVal.String()
+	case v.ObjectVal != nil:
+		return v.ObjectVal.String()
+	case v.ListVal != nil:
+		if active[v.ListVal] {
+			return "[...]"
+		}
+		active[v.ListVal] = true
+		defer delete(active, v.ListVal)
+
+		elements := make([]string, len(v.ListVal.Elements))
+		for idx, element := range v.ListVal.Elements {
+			elements[idx] = element.format(active)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case v.MapVal != nil:
+		if active[v.MapVal] {
+			return "{...}"
+		}
+		active[v.MapVal] = true
+		defer delete(active, v.MapVal)
+
+		entries := make([]string, 0, v.MapVal.Len())
+		for _, entry := range v.MapVal.entries {
+			entries = append(entries, entry.key.format(active)+": "+entry.value.format(active))
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	case v.RangeVal != nil:
+		return fmt.Sprintf("%d..%d", v.RangeVal.Start, v.RangeVal.End)
 	default:
 		return "nil"
 	}
 }
 
+// Equals reports whether v and other are the same value. An int equals
+// a float only if the float converts to exactly that int, lists and maps
+// compare element-wise, and functions and objects compare by identity.
+func (v *Value) Equals(other *Value) bool {
+	return v.equals(other, make(map[containerPair]bool))
+}
+
+// ExactInt returns the int f represents exactly, failing if f has a
+// fractional part or lies outside the range of int.
+func ExactInt(f float64) (int, bool) {
+	if f != math.Trunc(f) || f < math.MinInt || f >= math.MaxInt {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// containerPair is two lists or two maps being compared.
+type containerPair struct {
+	left, right any
+}
+
+// equals is Equals for values nested in the containers in active. A pair
+// met again while still being compared is assumed equal; any difference
+// is found along another path, so self-referential containers terminate.
+func (v *Value) equals(other *Value, active map[containerPair]bool) bool {
+	switch {
+	case v.IsNil() || other.IsNil():
+		return v.IsNil() && other.IsNil()
+	case v.IntVal != nil && other.IntVal != nil:
+		return *v.IntVal == *other.IntVal
+	case v.FloatVal != nil && other.FloatVal != nil:
+		return *v.FloatVal == *other.FloatVal
+	case v.IntVal != nil && other.FloatVal != nil:
+		n, ok := ExactInt(*other.FloatVal)
+		return ok && n == *v.IntVal
+	case v.FloatVal != nil && other.IntVal != nil:
+		n, ok := ExactInt(*v.FloatVal)
+		return ok && n == *other.IntVal
+	case v.StrVal != nil && other.StrVal != nil:
+		return *v.StrVal == *other.StrVal
+	case v.BoolVal != nil && other.BoolVal != nil:
+		return *v.BoolVal == *other.BoolVal
+	case v.CallableVal != nil && other.CallableVal != nil:
+		return v.CallableVal == other.CallableVal
+	case v.ObjectVal != nil && other.ObjectVal != nil:
+		return v.ObjectVal == other.ObjectVal
+	case v.RangeVal != nil && other.RangeVal != nil:
+		return *v.RangeVal == *other.RangeVal
+	case v.ListVal != nil && other.ListVal != nil:
+		if len(v.ListVal.Elements) != len(other.ListVal.Elements) {
+			return false
+		}
+		pair := containerPair{v.ListVal, other.ListVal}
+		if active[pair] {
+			return true
+		}
+		active[pair] = true
+		defer delete(active, pair)
+
+		for idx, element := range v.ListVal.Elements {
+			if !element.equals(other.ListVal.Elements[idx], active) {
+				return false
+			}
+		}
+		return true
+	case v.MapVal != nil && other.MapVal != nil:
+		if v.MapVal.Len() != other.MapVal.Len() {
+			return false
+		}
+		pair := containerPair{v.MapVal, other.MapVal}
+		if active[pair] {
+			return true
+		}
+		active[pair] = true
+		defer delete(active, pair)
+
+		for _, entry := range v.MapVal.entries {
+			value, ok := other.MapVal.Get(entry.key)
+			if !ok || !entry.value.equals(value, active) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
 func (v Value) GetType() string {
 	switch {
 	case v.IntVal != nil:
@@ -70,6 +270,16 @@ func (v Value) GetType() string {
 		return "string"
 	case v.BoolVal != nil:
 		return "bool"
+	case v.CallableVal != nil:
+		return "function"
+	case v.ObjectVal != nil:
+		return "object"
+	case v.ListVal != nil:
+		return "list"
+	case v.MapVal != nil:
+		return "map"
+	case v.RangeVal != nil:
+		return "range"
 	default:
 		return "nil"
 	}
@@ -79,6 +289,10 @@ func (v Value) IsNumber() bool {
 	return v.IntVal != nil || v.FloatVal != nil
 }
 
+func (v Value) IsInt() bool {
+	return v.IntVal != nil
+}
+
 func (v Value) IsString() bool {
 	return v.StrVal != nil
 }
@@ -103,8 +317,30 @@ func (v Value) IsBool() bool {
 	return v.BoolVal != nil
 }
 
+func (v Value) IsCallable() bool {
+	return v.CallableVal != nil
+}
+
+func (v Value) IsObject() bool {
+	return v.ObjectVal != nil
+}
+
+func (v Value) IsList() bool {
+	return v.ListVal != nil
+}
+
+func (v Value) IsMap() bool {
+	return v.MapVal != nil
+}
+
+func (v Value) IsRange() bool {
+	return v.RangeVal != nil
+}
+
 func (v Value) IsNil() bool {
-	return v.StrVal == nil && v.IntVal == nil && v.FloatVal == nil && v.BoolVal == nil
+	return v.StrVal == nil && v.IntVal == nil && v.FloatVal == nil && v.BoolVal == nil &&
+		v.CallableVal == nil && v.ObjectVal == nil && v.ListVal == nil && v.MapVal == nil &&
+		v.RangeVal == nil
 }
 
 func (v Value) IsTruthy() bool {
@@ -117,6 +353,14 @@ func (v Value) IsTruthy() bool {
 		return *v.FloatVal != 0.0
 	case v.StrVal != nil:
 		return *v.StrVal != ""
+	case v.ListVal != nil:
+		return len(v.ListVal.Elements) > 0
+	case v.MapVal != nil:
+		return v.MapVal.Len() > 0
+	case v.RangeVal != nil:
+		return v.RangeVal.Len() > 0
+	case v.CallableVal != nil, v.ObjectVal != nil:
+		return true
 	default:
 		return false // nil is false
 	}
@@ -125,6 +369,7 @@ func (v Value) IsTruthy() bool {
 type Expr interface {
 	Type() ExprType
 	String() string
+	Span() ls.Span
 	Accept(visitor ExprVisitor) *Value
 }
 
@@ -134,6 +379,22 @@ type ExprVisitor interface {
 	VisitLiteral(literal *Literal) *Value
 	VisitVariable(variable *Variable) *Value
 	VisitAssign(assign *Assign) *Value
+	VisitLogical(logical *Logical) *Value
+	VisitCall(call *Call) *Value
+	VisitGet(get *Get) *Value
+	VisitSet(set *Set) *Value
+	VisitThis(this *This) *Value
+	VisitSuper(super *Super) *Value
+	VisitConditional(conditional *Conditional) *Value
+	VisitUpdate(update *Update) *Value
+	VisitListLiteral(list *ListLiteral) *Value
+	VisitIndex(index *Index) *Value
+	VisitIndexSet(indexSet *IndexSet) *Value
+	VisitSlice(slice *Slice) *Value
+	VisitMapLiteral(m *MapLiteral) *Value
+	VisitLambda(lambda *Lambda) *Value
+	VisitRange(r *RangeExpr) *Value
+	VisitDestructureAssign(expr *DestructureAssign) *Value
 }
 
 type Binary struct {
@@ -150,6 +411,10 @@ func (b *Binary) String() string {
 	return fmt.Sprintf("(%s %s %s)", b.Left, b.Operator.Lexeme, b.Right)
 }
 
+func (b *Binary) Span() ls.Span {
+	return b.Left.Span().Merge(b.Right.Span())
+}
+
 func (b *Binary) Accept(visitor ExprVisitor) *Value {
 	return visitor.VisitBinary(b)
 }
@@ -167,11 +432,16 @@ func (u *Unary) String() string {
 	return fmt.Sprintf("(%s %s)", u.Operator.Lexeme, u.Right)
 }
 
+func (u *Unary) Span() ls.Span {
+	return u.Operator.Span().Merge(u.Right.Span())
+}
+
 func (u *Unary) Accept(visitor ExprVisitor) *Value {
 	return visitor.VisitUnary(u)
 }
 
 type Literal struct {
+	Token *ls.Token
 	Value *Value
 }
 
@@ -183,12 +453,16 @@ func (l *Literal) String() string {
 	return l.Value.String()
 }
 
+func (l *Literal) Span() ls.Span {
+	return l.Token.Span()
+}
+
 func (l *Literal) Accept(visitor ExprVisitor) *Value {
 	return visitor.VisitLiteral(l)
 }
 
 type Variable struct {
-	Name string
+	Name *ls.Token
 }
 
 func (v *Variable) Type() ExprType {
@@ -196,16 +470,23 @@ func (v *Variable) Type() ExprType {
 }
 
 func (v *Variable) String() string {
-	return v.Name
+	return v.Name.Lexeme
+}
+
+func (v *Variable) Span() ls.Span {
+	return v.Name.Span()
 }
 
 func (v *Variable) Accept(visitor ExprVisitor) *Value {
 	return visitor.VisitVariable(v)
 }
 
+// Assign stores Expr in a variable. For a compound assignment such as
+// "+=", Operator is the binary operator applied to the old value.
 type Assign struct {
-	Name string
-	Expr Expr
+	Name     *ls.Token
+	Operator *ls.Token
+	Expr     Expr
 }
 
 func (a *Assign) Type() ExprType {
@@ -213,9 +494,419 @@ func (a *Assign) Type() ExprType {
 }
 
 func (a *Assign) String() string {
-	return fmt.Sprintf("%s = %s", a.Name, a.Expr)
+	if a.Operator != nil {
+		return fmt.Sprintf("%s %s= %s", a.Name.Lexeme, a.Operator.Lexeme, a.Expr)
+	}
+	return fmt.Sprintf("%s = %s", a.Name.Lexeme, a.Expr)
+}
+
+func (a *Assign) Span() ls.Span {
+	return a.Name.Span().Merge(a.Expr.Span())
 }
 
 func (a *Assign) Accept(visitor ExprVisitor) *Value {
 	return visitor.VisitAssign(a)
 }
+
+type Logical struct {
+	Left     Expr
+	Operator *ls.Token
+	Right    Expr
+}
+
+func (l *Logical) Type() ExprType {
+	return LOGICAL
+}
+
+func (l *Logical) String() string {
+	return fmt.Sprintf("(%s %s %s)", l.Left, l.Operator.Lexeme, l.Right)
+}
+
+func (l *Logical) Span() ls.Span {
+	return l.Left.Span().Merge(l.Right.Span())
+}
+
+func (l *Logical) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitLogical(l)
+}
+
+type Call struct {
+	Callee    Expr
+	Paren     *ls.Token
+	Arguments []Expr
+}
+
+func (c *Call) Type() ExprType {
+	return CALL
+}
+
+func (c *Call) String() string {
+	args := make([]string, len(c.Arguments))
+	for idx, arg := range c.Arguments {
+		args[idx] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", c.Callee, strings.Join(args, ", "))
+}
+
+func (c *Call) Span() ls.Span {
+	return c.Callee.Span().Merge(c.Paren.Span())
+}
+
+func (c *Call) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitCall(c)
+}
+
+type Get struct {
+	Object Expr
+	Name   *ls.Token
+}
+
+func (g *Get) Type() ExprType {
+	return GET
+}
+
+func (g *Get) String() string {
+	return fmt.Sprintf("%s.%s", g.Object, g.Name.Lexeme)
+}
+
+func (g *Get) Span() ls.Span {
+	return g.Object.Span().Merge(g.Name.Span())
+}
+
+func (g *Get) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitGet(g)
+}
+
+// Set stores Value in a property, applying Operator first for a
+// compound assignment.
+type Set struct {
+	Object   Expr
+	Name     *ls.Token
+	Operator *ls.Token
+	Value    Expr
+}
+
+func (s *Set) Type() ExprType {
+	return SET
+}
+
+func (s *Set) String() string {
+	if s.Operator != nil {
+		return fmt.Sprintf("%s.%s %s= %s", s.Object, s.Name.Lexeme, s.Operator.Lexeme, s.Value)
+	}
+	return fmt.Sprintf("%s.%s = %s", s.Object, s.Name.Lexeme, s.Value)
+}
+
+func (s *Set) Span() ls.Span {
+	return s.Object.Span().Merge(s.Value.Span())
+}
+
+func (s *Set) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitSet(s)
+}
+
+type This struct {
+	Keyword *ls.Token
+}
+
+func (t *This) Type() ExprType {
+	return THIS
+}
+
+func (t *This) String() string {
+	return "this"
+}
+
+func (t *This) Span() ls.Span {
+	return t.Keyword.Span()
+}
+
+func (t *This) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitThis(t)
+}
+
+type Super struct {
+	Keyword *ls.Token
+	Method  *ls.Token
+}
+
+func (s *Super) Type() ExprType {
+	return SUPER
+}
+
+func (s *Super) String() string {
+	return fmt.Sprintf("super.%s", s.Method.Lexeme)
+}
+
+func (s *Super) Span() ls.Span {
+	return s.Keyword.Span().Merge(s.Method.Span())
+}
+
+func (s *Super) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitSuper(s)
+}
+
+type Conditional struct {
+	Condition  Expr
+	ThenBranch Expr
+	ElseBranch Expr
+}
+
+func (c *Conditional) Type() ExprType {
+	return CONDITIONAL
+}
+
+func (c *Conditional) String() string {
+	return fmt.Sprintf("(%s ? %s : %s)", c.Condition, c.ThenBranch, c.ElseBranch)
+}
+
+func (c *Conditional) Span() ls.Span {
+	return c.Condition.Span().Merge(c.ElseBranch.Span())
+}
+
+func (c *Conditional) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitConditional(c)
+}
+
+// Update is a prefix or postfix "++" or "--" applied to an assignable
+// Target. Prefix forms yield the new value, postfix forms the old one.
+type Update struct {
+	Target   Expr
+	Operator *ls.Token
+	Prefix   bool
+}
+
+func (u *Update) Type() ExprType {
+	return UPDATE
+}
+
+func (u *Update) String() string {
+	if u.Prefix {
+		return fmt.Sprintf("(%s%s)", u.Operator.Lexeme, u.Target)
+	}
+	return fmt.Sprintf("(%s%s)", u.Target, u.Operator.Lexeme)
+}
+
+func (u *Update) Span() ls.Span {
+	return u.Target.Span().Merge(u.Operator.Span())
+}
+
+func (u *Update) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitUpdate(u)
+}
+
+type ListLiteral struct {
+	LeftBracket  *ls.Token
+	Elements     []Expr
+	RightBracket *ls.Token
+}
+
+func (l *ListLiteral) Type() ExprType {
+	return LIST
+}
+
+func (l *ListLiteral) String() string {
+	elements := make([]string, len(l.Elements))
+	for idx, element := range l.Elements {
+		elements[idx] = element.String()
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+func (l *ListLiteral) Span() ls.Span {
+	return l.LeftBracket.Span().Merge(l.RightBracket.Span())
+}
+
+func (l *ListLiteral) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitListLiteral(l)
+}
+
+// Index reads Object[Index]. Bracket is the closing bracket.
+type Index struct {
+	Object  Expr
+	Bracket *ls.Token
+	Index   Expr
+}
+
+func (i *Index) Type() ExprType {
+	return INDEX
+}
+
+func (i *Index) String() string {
+	return fmt.Sprintf("%s[%s]", i.Object, i.Index)
+}
+
+func (i *Index) Span() ls.Span {
+	return i.Object.Span().Merge(i.Bracket.Span())
+}
+
+func (i *Index) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitIndex(i)
+}
+
+// IndexSet stores Value at Object[Index], applying Operator first for a
+// compound assignment.
+type IndexSet struct {
+	Object   Expr
+	Bracket  *ls.Token
+	Index    Expr
+	Operator *ls.Token
+	Value    Expr
+}
+
+func (i *IndexSet) Type() ExprType {
+	return INDEX_SET
+}
+
+func (i *IndexSet) String() string {
+	if i.Operator != nil {
+		return fmt.Sprintf("%s[%s] %s= %s", i.Object, i.Index, i.Operator.Lexeme, i.Value)
+	}
+	return fmt.Sprintf("%s[%s] = %s", i.Object, i.Index, i.Value)
+}
+
+func (i *IndexSet) Span() ls.Span {
+	return i.Object.Span().Merge(i.Value.Span())
+}
+
+func (i *IndexSet) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitIndexSet(i)
+}
+
+// Slice reads Object[Start:End]. Either bound may be nil.
+type Slice struct {
+	Object  Expr
+	Bracket *ls.Token
+	Start   Expr
+	End     Expr
+}
+
+func (s *Slice) Type() ExprType {
+	return SLICE
+}
+
+func (s *Slice) String() string {
+	start, end := "", ""
+	if s.Start != nil {
+		start = s.Start.String()
+	}
+	if s.End != nil {
+		end = s.End.String()
+	}
+	return fmt.Sprintf("%s[%s:%s]", s.Object, start, end)
+}
+
+func (s *Slice) Span() ls.Span {
+	return s.Object.Span().Merge(s.Bracket.Span())
+}
+
+func (s *Slice) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitSlice(s)
+}
+
+type MapLiteral struct {
+	LeftBrace  *ls.Token
+	Keys       []Expr
+	Values     []Expr
+	RightBrace *ls.Token
+}
+
+func (m *MapLiteral) Type() ExprType {
+	return MAP
+}
+
+func (m *MapLiteral) String() string {
+	entries := make([]string, len(m.Keys))
+	for idx, key := range m.Keys {
+		entries[idx] = key.String() + ": " + m.Values[idx].String()
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+func (m *MapLiteral) Span() ls.Span {
+	return m.LeftBrace.Span().Merge(m.RightBrace.Span())
+}
+
+func (m *MapLiteral) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitMapLiteral(m)
+}
+
+// Lambda is an anonymous function expression, written either with "fun"
+// or with the arrow shorthand. Its Function has a nil Name.
+type Lambda struct {
+	Keyword  *ls.Token // "fun" or "=>"
+	Function *FunctionStmt
+	span     ls.Span
+}
+
+func (l *Lambda) Type() ExprType {
+	return LAMBDA
+}
+
+func (l *Lambda) String() string {
+	params := make([]string, len(l.Function.Params))
+	for idx, param := range l.Function.Params {
+		params[idx] = param.Lexeme
+	}
+	return fmt.Sprintf("fun (%s)", strings.Join(params, ", "))
+}
+
+func (l *Lambda) Span() ls.Span {
+	return l.span
+}
+
+func (l *Lambda) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitLambda(l)
+}
+
+// RangeExpr builds a Range from Start up to but excluding End.
+type RangeExpr struct {
+	Start    Expr
+	Operator *ls.Token
+	End      Expr
+}
+
+func (r *RangeExpr) Type() ExprType {
+	return RANGE
+}
+
+func (r *RangeExpr) String() string {
+	return fmt.Sprintf("(%s..%s)", r.Start, r.End)
+}
+
+func (r *RangeExpr) Span() ls.Span {
+	return r.Start.Span().Merge(r.End.Span())
+}
+
+func (r *RangeExpr) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitRange(r)
+}
+
+// DestructureAssign assigns the elements of the list Value evaluates to
+// to Targets, in order. Value is evaluated in full first, so
+// "[a, b] = [b, a]" swaps.
+type DestructureAssign struct {
+	Bracket *ls.Token
+	Targets []*Variable
+	Value   Expr
+}
+
+func (da *DestructureAssign) Type() ExprType {
+	return DESTRUCTURE_ASSIGN
+}
+
+func (da *DestructureAssign) String() string {
+	targets := make([]string, len(da.Targets))
+	for idx, target := range da.Targets {
+		targets[idx] = target.String()
+	}
+	return fmt.Sprintf("(= [%s] %s)", strings.Join(targets, ", "), da.Value)
+}
+
+func (da *DestructureAssign) Span() ls.Span {
+	return da.Bracket.Span().Merge(da.Value.Span())
+}
+
+func (da *DestructureAssign) Accept(visitor ExprVisitor) *Value {
+	return visitor.VisitDestructureAssign(da)
+}
@@ -0,0 +1,114 @@
+package parser
+
+// hashKey is the Go map key for a hashable Value. Kind keeps values of
+// different types apart, so the string "1" and the int 1 never collide.
+type hashKey struct {
+	kind string
+	key  any
+}
+
+// hashKey returns the key used to store v in a Map. Equal values have
+// equal keys: a float with an integral value hashes like the matching
+// int. Lists and maps are mutable and therefore not hashable; functions
+// and objects hash by identity.
+func (v *Value) hashKey() (hashKey, bool) {
+	switch {
+	case v.IntVal != nil:
+		return hashKey{"number", *v.IntVal}, true
+	case v.FloatVal != nil:
+		if n, ok := ExactInt(*v.FloatVal); ok {
+			return hashKey{"number", n}, true
+		}
+		return hashKey{"number", *v.FloatVal}, true
+	case v.StrVal != nil:
+		return hashKey{"string", *v.StrVal}, true
+	case v.BoolVal != nil:
+		return hashKey{"bool", *v.BoolVal}, true
+	case v.CallableVal != nil:
+		return hashKey{"callable", v.CallableVal}, true
+	case v.ObjectVal != nil:
+		return hashKey{"object", v.ObjectVal}, true
+	case v.RangeVal != nil:
+		return hashKey{"range", *v.RangeVal}, true
+	case v.ListVal != nil, v.MapVal != nil:
+		return hashKey{}, false
+	default:
+		return hashKey{"nil", nil}, true
+	}
+}
+
+type mapEntry struct {
+	key   *Value
+	value *Value
+}
+
+// Map is a mutable dictionary that remembers insertion order. Values
+// holding the same *Map share it.
+type Map struct {
+	entries []mapEntry
+	index   map[hashKey]int
+}
+
+func NewMap() *Map {
+	return &Map{
+		entries: make([]mapEntry, 0),
+		index:   make(map[hashKey]int),
+	}
+}
+
+// Get returns the value stored under key. It reports false if key is
+// absent or unhashable.
+func (m *Map) Get(key *Value) (*Value, bool) {
+	hk, ok := key.hashKey()
+	if !ok {
+		return nil, false
+	}
+	idx, ok := m.index[hk]
+	if !ok {
+		return nil, false
+	}
+	return m.entries[idx].value, true
+}
+
+// Set stores value under key, keeping the original position of an
+// existing key. It reports false if key is unhashable.
+func (m *Map) Set(key, value *Value) bool {
+	hk, ok := key.hashKey()
+	if !ok {
+		return false
+	}
+	if idx, exists := m.index[hk]; exists {
+		m.entries[idx].value = value
+		return true
+	}
+	m.index[hk] = len(m.entries)
+	m.entries = append(m.entries, mapEntry{key: key, value: value})
+	return true
+}
+
+func (m *Map) Has(key *Value) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+func (m *Map) Len() int {
+	return len(m.entries)
+}
+
+// Keys returns the keys in insertion order.
+func (m *Map) Keys() []*Value {
+	keys := make([]*Value, len(m.entries))
+	for idx, entry := range m.entries {
+		keys[idx] = entry.key
+	}
+	return keys
+}
+
+// Values returns the values in key insertion order.
+func (m *Map) Values() []*Value {
+	values := make([]*Value, len(m.entries))
+	for idx, entry := range m.entries {
+		values[idx] = entry.value
+	}
+	return values
+}
@@ -1,72 +1,455 @@
 package parser
 
 import (
-	"errors"
-	"log"
+	"fmt"
 
 	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
 )
 
 // Grammar to parse
 // program        → declaration* EOF
-// declaration    → classDecl | funDecl | varDecl | statement
+// declaration    → classDecl | enumDecl | funDecl | varDecl | constDecl | importDecl
+//                | exportDecl | statement
+// enumDecl       → "enum" IDENTIFIER "{" ( IDENTIFIER ( "," IDENTIFIER )* ","? )? "}"
+// importDecl     → "import" STRING "as" IDENTIFIER ";"
+// exportDecl     → "export" ( classDecl | enumDecl | funDecl | varDecl | constDecl )
 // classDecl      → "class" IDENTIFIER ( "<" IDENTIFIER )? "{" function* "}"
 // funDecl        → "fun" function
+// function       → IDENTIFIER "(" parameters? ")" block
+// parameters     → IDENTIFIER ( "," IDENTIFIER )*
 // varDecl        → "var" IDENTIFIER ( "=" expression )? ";"
-// statement      → exprStmt | ifStmt | printStmt | returnStmt | whileStmt | block
+//                | "var" destructure "=" expression ";"
+// constDecl      → "const" ( IDENTIFIER | destructure ) "=" expression ";"
+// destructure    → "[" names "]" | "{" names "}"
+// names          → IDENTIFIER ( "," IDENTIFIER )*
+// statement      → exprStmt | forStmt | ifStmt | matchStmt | printStmt | returnStmt
+//                | throwStmt | tryStmt | whileStmt | block
+// block          → "{" declaration* "}"
+// ifStmt         → "if" "(" expression ")" statement ( "else" statement )?
+// matchStmt      → "match" "(" expression ")" "{" matchArm* "}"
+// matchArm       → "case" pattern ( "if" expression )? "=>" statement
+// pattern        → simplePattern ( "|" simplePattern )*
+// simplePattern  → NUMBER | "-" NUMBER | STRING | "true" | "false" | "nil"
+//                | "_" | IDENTIFIER | IDENTIFIER "." IDENTIFIER
+//                | "[" ( pattern ( "," pattern )* )? "]"
+// returnStmt     → "return" expression? ";"
+// throwStmt      → "throw" expression ";"
+// tryStmt        → "try" block ( "catch" "(" IDENTIFIER ")" block )? ( "finally" block )?
+// whileStmt      → "while" "(" expression ")" statement
+// forStmt        → "for" "(" ( varDecl | exprStmt | ";" ) expression? ";" expression? ")" statement
+//                | "for" "(" "var"? IDENTIFIER "in" expression ")" statement
 // exprStmt       → expression ";"
 // expression     → assignment
-// assignment     → IDENTIFIER "=" assignment | equality
+// assignment     → ( call "." IDENTIFIER | call "[" expression "]" | IDENTIFIER ) assignOp assignment
+//                | "[" names "]" "=" assignment
+//                | conditional
+// assignOp       → "=" | "+=" | "-=" | "*=" | "/=" | "%="
+// conditional    → logic_or ( "?" expression ":" conditional )?
+// logic_or       → logic_and ( "or" logic_and )*
+// logic_and      → equality ( "and" equality )*
 // equality       → comparison ( ( "!=" | "==" ) comparison )*
-// comparison     → term ( ( ">" | ">=" | "<" | "<=" ) term )*
+// comparison     → range ( ( ">" | ">=" | "<" | "<=" | "in" ) range )*
+// range          → bit_or ( ".." bit_or )?
+// bit_or         → bit_xor ( "|" bit_xor )*
+// bit_xor        → bit_and ( "^" bit_and )*
+// bit_and        → shift ( "&" shift )*
+// shift          → term ( ( "<<" | ">>" ) term )*
 // term           → factor ( ( "-" | "+" ) factor )*
-// factor         → unary ( ( "/" | "*" ) unary )*
-// unary          → ( "!" | "-" ) unary
-//                | primary
-// primary        → NUMBER | STRING | "true" | "false" | "nil"
+// factor         → unary ( ( "/" | "~/" | "*" | "%" ) unary )*
+// unary          → ( "!" | "-" | "~" | "++" | "--" ) unary
+//                | power
+// power          → postfix ( "**" unary )?
+// postfix        → call ( "++" | "--" )?
+// call           → primary ( "(" arguments? ")" | "." IDENTIFIER | "[" subscript "]" )*
+// subscript      → expression | expression? ":" expression?
+// arguments      → expression ( "," expression )*
+// primary        → NUMBER | STRING | "true" | "false" | "nil" | "this"
 //                | "(" expression ")"
-//                | IDENTIFIER
+//                | "[" ( expression ( "," expression )* ","? )? "]"
+//                | "{" ( entry ( "," entry )* ","? )? "}"
+//                | IDENTIFIER | "super" "." IDENTIFIER
+//                | lambda
+// entry          → expression ":" expression
+// lambda         → "fun" "(" parameters? ")" block
+//                | ( IDENTIFIER | "(" parameters? ")" ) "=>" ( block | expression )
+
+// ParseError describes a syntax error at a particular token.
+type ParseError struct {
+	Token   ls.Token
+	Message string
+}
+
+func (e ParseError) Error() string {
+	where := fmt.Sprintf("'%s'", e.Token.Lexeme)
+	if e.Token.Type == ls.EOF {
+		where = "end"
+	}
+	return fmt.Sprintf("[line %d:%d] Error at %s: %s", e.Token.Line, e.Token.Column, where, e.Message)
+}
 
 type Parser struct {
 	tokens  []ls.Token
 	current int
+	errors  []ParseError
+	// inGuard is set while parsing the top level of a match guard, whose
+	// trailing "=>" must not be read as an arrow function. It is cleared
+	// inside brackets, where the guard can't end.
+	inGuard bool
 }
 
 func NewParser(tokens []ls.Token) *Parser {
 	return &Parser{
 		tokens:  tokens,
 		current: 0,
+		errors:  make([]ParseError, 0),
 	}
 }
 
-func (p *Parser) Parse() []Stmt {
+// Parse parses the whole token stream. After a syntax error the parser
+// skips to the next statement boundary and carries on, so every error in
+// the input is returned rather than just the first.
+func (p *Parser) Parse() ([]Stmt, []ParseError) {
 	var stmts []Stmt
 	for !p.isAtEnd() {
 		stmt := p.declaration()
-		stmts = append(stmts, stmt)
+		if stmt != nil {
+			stmts = append(stmts, stmt)
+		}
 	}
-	return stmts
+	return stmts, p.errors
 }
 
-func (p *Parser) declaration() Stmt {
+// declaration recovers from a ParseError raised anywhere below it,
+// records it and resynchronizes, returning a nil statement.
+func (p *Parser) declaration() (stmt Stmt) {
+	defer func() {
+		if r := recover(); r != nil {
+			parseErr, ok := r.(ParseError)
+			if !ok {
+				panic(r)
+			}
+			p.errors = append(p.errors, parseErr)
+			p.synchronize()
+			stmt = nil
+		}
+	}()
+
+	if p.match(ls.CLASS) {
+		return p.classDeclaration()
+	}
+	if p.match(ls.ENUM) {
+		return p.enumDeclaration()
+	}
+	// "fun" without a name starts an anonymous function expression instead.
+	if p.check(ls.FUN) && p.checkNext(ls.IDENTIFIER) {
+		p.advance()
+		return p.function("function")
+	}
 	if p.match(ls.VAR) {
 		return p.varDeclaration()
 	}
+	if p.match(ls.CONST) {
+		return p.constDeclaration()
+	}
+	if p.match(ls.IMPORT) {
+		return p.importDeclaration()
+	}
+	if p.match(ls.EXPORT) {
+		return p.exportDeclaration()
+	}
 	return p.statement()
 }
 
+// importDecl → "import" STRING "as" IDENTIFIER ";"
+func (p *Parser) importDeclaration() Stmt {
+	keyword := p.previous()
+	path := p.consume(ls.STRING, "expect module path after 'import'")
+	p.consume(ls.AS, "expect 'as' after module path")
+	alias := p.consume(ls.IDENTIFIER, "expect module name after 'as'")
+	p.consume(ls.SEMICOLON, "expect ';' after import")
+
+	return &ImportStmt{
+		Keyword: &keyword,
+		Path:    &path,
+		Alias:   &alias,
+	}
+}
+
+// exportDecl → "export" ( classDecl | enumDecl | funDecl | varDecl | constDecl )
+func (p *Parser) exportDeclaration() Stmt {
+	keyword := p.previous()
+	var declaration Stmt
+	switch {
+	case p.match(ls.CLASS):
+		declaration = p.classDeclaration()
+	case p.match(ls.ENUM):
+		declaration = p.enumDeclaration()
+	case p.check(ls.FUN) && p.checkNext(ls.IDENTIFIER):
+		p.advance()
+		declaration = p.function("function")
+	case p.match(ls.VAR):
+		declaration = p.varDeclaration()
+	case p.match(ls.CONST):
+		declaration = p.constDeclaration()
+	default:
+		panic(p.error(p.peek(), "expect declaration after 'export'"))
+	}
+
+	return &ExportStmt{
+		Keyword:     &keyword,
+		Declaration: declaration,
+	}
+}
+
+// enumDecl → "enum" IDENTIFIER "{" ( IDENTIFIER ( "," IDENTIFIER )* ","? )? "}"
+func (p *Parser) enumDeclaration() Stmt {
+	name := p.consume(ls.IDENTIFIER, "expect enum name")
+	p.consume(ls.LEFT_BRACE, "expect '{' before enum members")
+
+	var members []*ls.Token
+	for !p.check(ls.RIGHT_BRACE) && !p.isAtEnd() {
+		member := p.consume(ls.IDENTIFIER, "expect enum member name")
+		members = append(members, &member)
+		if !p.match(ls.COMMA) {
+			break
+		}
+	}
+	p.consume(ls.RIGHT_BRACE, "expect '}' after enum members")
+
+	return &EnumStmt{
+		Name:    &name,
+		Members: members,
+	}
+}
+
+// parameters → IDENTIFIER ( "," IDENTIFIER )*
+//
+// parameters parses up to and including the closing ")".
+func (p *Parser) parameters() []*ls.Token {
+	var params []*ls.Token
+	if !p.check(ls.RIGHT_PAREN) {
+		for {
+			if len(params) >= maxArgs {
+				p.errors = append(p.errors, p.error(p.peek(), fmt.Sprintf("can't have more than %d parameters", maxArgs)))
+			}
+			param := p.consume(ls.IDENTIFIER, "expect parameter name")
+			params = append(params, &param)
+			if !p.match(ls.COMMA) {
+				break
+			}
+		}
+	}
+	p.consume(ls.RIGHT_PAREN, "expect ')' after parameters")
+	return params
+}
+
+// classDecl → "class" IDENTIFIER ( "<" IDENTIFIER )? "{" function* "}"
+func (p *Parser) classDeclaration() Stmt {
+	name := p.consume(ls.IDENTIFIER, "expect class name")
+
+	var superclass *Variable
+	if p.match(ls.LESS) {
+		superName := p.consume(ls.IDENTIFIER, "expect superclass name")
+		superclass = &Variable{
+			Name: &superName,
+		}
+	}
+
+	p.consume(ls.LEFT_BRACE, "expect '{' before class body")
+
+	var methods []*FunctionStmt
+	for !p.check(ls.RIGHT_BRACE) && !p.isAtEnd() {
+		methods = append(methods, p.function("method"))
+	}
+	p.consume(ls.RIGHT_BRACE, "expect '}' after class body")
+
+	return &ClassStmt{
+		Name:       &name,
+		Superclass: superclass,
+		Methods:    methods,
+	}
+}
+
+// maxArgs bounds the number of parameters and call arguments.
+const maxArgs = 255
+
+// function → IDENTIFIER "(" parameters? ")" block
+func (p *Parser) function(kind string) *FunctionStmt {
+	name := p.consume(ls.IDENTIFIER, "expect "+kind+" name")
+	p.consume(ls.LEFT_PAREN, "expect '(' after "+kind+" name")
+	params := p.parameters()
+
+	p.consume(ls.LEFT_BRACE, "expect '{' before "+kind+" body")
+	body := p.block()
+
+	return &FunctionStmt{
+		Name:   &name,
+		Params: params,
+		Body:   body,
+	}
+}
+
 func (p *Parser) statement() Stmt {
+	if p.match(ls.FOR) {
+		return p.forStatement()
+	}
+	if p.match(ls.WHILE) {
+		return p.whileStatement()
+	}
+	if p.match(ls.IF) {
+		return p.ifStatement()
+	}
+	if p.match(ls.MATCH) {
+		return p.matchStatement()
+	}
 	if p.match(ls.PRINT) {
 		return p.printStatement()
 	}
+	if p.match(ls.RETURN) {
+		return p.returnStatement()
+	}
+	if p.match(ls.THROW) {
+		return p.throwStatement()
+	}
+	if p.match(ls.TRY) {
+		return p.tryStatement()
+	}
+	if p.match(ls.LEFT_BRACE) {
+		return &BlockStmt{
+			Stmts: p.block(),
+		}
+	}
 	return p.expressionStatement()
 }
 
+// block → "{" declaration* "}"
+func (p *Parser) block() []Stmt {
+	var stmts []Stmt
+	for !p.check(ls.RIGHT_BRACE) && !p.isAtEnd() {
+		stmt := p.declaration()
+		if stmt != nil {
+			stmts = append(stmts, stmt)
+		}
+	}
+	p.consume(ls.RIGHT_BRACE, "expect '}' after block")
+	return stmts
+}
+
+// ifStmt → "if" "(" expression ")" statement ( "else" statement )?
+func (p *Parser) ifStatement() Stmt {
+	p.consume(ls.LEFT_PAREN, "expect '(' after 'if'")
+	condition := p.expression()
+	p.consume(ls.RIGHT_PAREN, "expect ')' after if condition")
+
+	thenBranch := p.statement()
+	var elseBranch Stmt
+	if p.match(ls.ELSE) {
+		elseBranch = p.statement()
+	}
+
+	return &IfStmt{
+		Condition:  condition,
+		ThenBranch: thenBranch,
+		ElseBranch: elseBranch,
+	}
+}
+
+// whileStmt → "while" "(" expression ")" statement
+func (p *Parser) whileStatement() Stmt {
+	p.consume(ls.LEFT_PAREN, "expect '(' after 'while'")
+	condition := p.expression()
+	p.consume(ls.RIGHT_PAREN, "expect ')' after while condition")
+	body := p.statement()
+
+	return &WhileStmt{
+		Condition: condition,
+		Body:      body,
+	}
+}
+
+// forStmt → "for" "(" ( varDecl | exprStmt | ";" ) expression? ";" expression? ")" statement
+//
+// There is no for node: the loop is desugared into a while loop wrapped in
+// a block that scopes the initializer.
+func (p *Parser) forStatement() Stmt {
+	forToken := p.previous()
+	p.consume(ls.LEFT_PAREN, "expect '(' after 'for'")
+
+	if p.checkAt(0, ls.IDENTIFIER) && p.checkAt(1, ls.IN) ||
+		p.checkAt(0, ls.VAR) && p.checkAt(1, ls.IDENTIFIER) && p.checkAt(2, ls.IN) {
+		return p.forInStatement(forToken)
+	}
+
+	var initializer Stmt
+	if p.match(ls.SEMICOLON) {
+		initializer = nil
+	} else if p.match(ls.VAR) {
+		initializer = p.varDeclaration()
+	} else {
+		initializer = p.expressionStatement()
+	}
+
+	var condition Expr
+	if !p.check(ls.SEMICOLON) {
+		condition = p.expression()
+	}
+	p.consume(ls.SEMICOLON, "expect ';' after loop condition")
+
+	var increment Expr
+	if !p.check(ls.RIGHT_PAREN) {
+		increment = p.expression()
+	}
+	p.consume(ls.RIGHT_PAREN, "expect ')' after for clauses")
+
+	body := p.statement()
+
+	if increment != nil {
+		body = &BlockStmt{
+			Stmts: []Stmt{body, &ExpressionStmt{Expr: increment}},
+		}
+	}
+	if condition == nil {
+		trueToken := forToken
+		trueToken.Type = ls.TRUE
+		condition = &Literal{
+			Token: &trueToken,
+			Value: NewBoolValue(true),
+		}
+	}
+	body = &WhileStmt{
+		Condition: condition,
+		Body:      body,
+	}
+	if initializer != nil {
+		body = &BlockStmt{
+			Stmts: []Stmt{initializer, body},
+		}
+	}
+	return body
+}
+
+// forStmt → "for" "(" "var"? IDENTIFIER "in" expression ")" statement
+func (p *Parser) forInStatement(forToken ls.Token) Stmt {
+	p.match(ls.VAR)
+	name := p.consume(ls.IDENTIFIER, "expect loop variable name")
+	p.consume(ls.IN, "expect 'in' after loop variable")
+	iterable := p.expression()
+	p.consume(ls.RIGHT_PAREN, "expect ')' after for-in clause")
+	body := p.statement()
+
+	return &ForInStmt{
+		Keyword:  &forToken,
+		Name:     &name,
+		Iterable: iterable,
+		Body:     body,
+	}
+}
+
 func (p *Parser) varDeclaration() Stmt {
-	name, err := p.consume(ls.IDENTIFIER, "expect variable name")
-	if err != nil {
-		log.Fatal(err)
+	if p.check(ls.LEFT_BRACKET) || p.check(ls.LEFT_BRACE) {
+		return p.destructuringDeclaration(false)
 	}
+	name := p.consume(ls.IDENTIFIER, "expect variable name")
 
 	var initializer Expr
 
@@ -74,10 +457,7 @@ func (p *Parser) varDeclaration() Stmt {
 		initializer = p.ParseExpression()
 	}
 
-	_, err = p.consume(ls.SEMICOLON, "expect ';' after expression")
-	if err != nil {
-		log.Fatal(err)
-	}
+	p.consume(ls.SEMICOLON, "expect ';' after variable declaration")
 
 	return &VarStmt{
 		Name: &name,
@@ -85,23 +465,267 @@ func (p *Parser) varDeclaration() Stmt {
 	}
 }
 
+// constDecl → "const" ( IDENTIFIER | destructure ) "=" expression ";"
+func (p *Parser) constDeclaration() Stmt {
+	if p.check(ls.LEFT_BRACKET) || p.check(ls.LEFT_BRACE) {
+		return p.destructuringDeclaration(true)
+	}
+	name := p.consume(ls.IDENTIFIER, "expect constant name")
+	p.consume(ls.EQUAL, "expect '=' after constant name")
+	initializer := p.ParseExpression()
+	p.consume(ls.SEMICOLON, "expect ';' after constant declaration")
+
+	return &VarStmt{
+		Name:  &name,
+		Expr:  initializer,
+		Const: true,
+	}
+}
+
+// destructure → "[" names "]" | "{" names "}"
+func (p *Parser) destructuringDeclaration(isConst bool) Stmt {
+	open := p.advance()
+	record := open.Type == ls.LEFT_BRACE
+	closing, closingLexeme := ls.RIGHT_BRACKET, "]"
+	if record {
+		closing, closingLexeme = ls.RIGHT_BRACE, "}"
+	}
+
+	var names []*ls.Token
+	for {
+		name := p.consume(ls.IDENTIFIER, "expect variable name in destructuring pattern")
+		names = append(names, &name)
+		if !p.match(ls.COMMA) {
+			break
+		}
+	}
+	p.consume(closing, "expect '"+closingLexeme+"' after destructuring pattern")
+	p.consume(ls.EQUAL, "expect '=' after destructuring pattern")
+	initializer := p.ParseExpression()
+	p.consume(ls.SEMICOLON, "expect ';' after variable declaration")
+
+	return &DestructureStmt{
+		Open:   &open,
+		Names:  names,
+		Record: record,
+		Expr:   initializer,
+		Const:  isConst,
+	}
+}
+
 func (p *Parser) printStatement() Stmt {
 	expr := p.ParseExpression()
-	_, err := p.consume(ls.SEMICOLON, "expect ';' after expression")
-	if err != nil {
-		log.Fatal(err)
-	}
+	p.consume(ls.SEMICOLON, "expect ';' after expression")
 	return &PrintStmt{
 		Expr: expr,
 	}
 }
 
+// returnStmt → "return" expression? ";"
+func (p *Parser) returnStatement() Stmt {
+	keyword := p.previous()
+	var value Expr
+	if !p.check(ls.SEMICOLON) {
+		value = p.expression()
+	}
+	p.consume(ls.SEMICOLON, "expect ';' after return value")
+	return &ReturnStmt{
+		Keyword: &keyword,
+		Value:   value,
+	}
+}
+
+// matchStmt → "match" "(" expression ")" "{" matchArm* "}"
+func (p *Parser) matchStatement() Stmt {
+	keyword := p.previous()
+	p.consume(ls.LEFT_PAREN, "expect '(' after 'match'")
+	subject := p.expression()
+	p.consume(ls.RIGHT_PAREN, "expect ')' after match subject")
+	p.consume(ls.LEFT_BRACE, "expect '{' before match arms")
+
+	var arms []*MatchArm
+	for !p.check(ls.RIGHT_BRACE) && !p.isAtEnd() {
+		arms = append(arms, p.matchArm())
+	}
+	p.consume(ls.RIGHT_BRACE, "expect '}' after match arms")
+
+	return &MatchStmt{
+		Keyword: &keyword,
+		Subject: subject,
+		Arms:    arms,
+	}
+}
+
+// matchArm → "case" pattern ( "if" expression )? "=>" statement
+func (p *Parser) matchArm() *MatchArm {
+	keyword := p.consume(ls.CASE, "expect 'case' before match arm")
+	pattern := p.pattern()
+
+	var guard Expr
+	if p.match(ls.IF) {
+		guard = p.guard()
+	}
+	p.consume(ls.ARROW, "expect '=>' after pattern")
+
+	return &MatchArm{
+		Keyword: &keyword,
+		Pattern: pattern,
+		Guard:   guard,
+		Body:    p.statement(),
+	}
+}
+
+func (p *Parser) guard() Expr {
+	inGuard := p.inGuard
+	p.inGuard = true
+	defer func() {
+		p.inGuard = inGuard
+	}()
+	return p.expression()
+}
+
+// enterBrackets clears inGuard until the returned function restores it.
+// Call it on entering any bracketed construct inside an expression.
+func (p *Parser) enterBrackets() func() {
+	inGuard := p.inGuard
+	p.inGuard = false
+	return func() {
+		p.inGuard = inGuard
+	}
+}
+
+// pattern → simplePattern ( "|" simplePattern )*
+func (p *Parser) pattern() Pattern {
+	pattern := p.simplePattern()
+	if !p.check(ls.PIPE) {
+		return pattern
+	}
+	alternatives := []Pattern{pattern}
+	for p.match(ls.PIPE) {
+		alternatives = append(alternatives, p.simplePattern())
+	}
+	return &AlternativePattern{
+		Alternatives: alternatives,
+	}
+}
+
+func (p *Parser) simplePattern() Pattern {
+	if p.match(ls.LEFT_BRACKET) {
+		bracket := p.previous()
+		var elements []Pattern
+		if !p.check(ls.RIGHT_BRACKET) {
+			for {
+				elements = append(elements, p.pattern())
+				if !p.match(ls.COMMA) {
+					break
+				}
+			}
+		}
+		p.consume(ls.RIGHT_BRACKET, "expect ']' after list pattern")
+		return &ListPattern{
+			Bracket:  &bracket,
+			Elements: elements,
+		}
+	}
+
+	if p.match(ls.IDENTIFIER) {
+		name := p.previous()
+		if p.match(ls.DOT) {
+			member := p.consume(ls.IDENTIFIER, "expect member name after '.'")
+			return &MemberPattern{
+				Value: &Get{
+					Object: &Variable{Name: &name},
+					Name:   &member,
+				},
+			}
+		}
+		if name.Lexeme == "_" {
+			return &WildcardPattern{
+				Token: &name,
+			}
+		}
+		return &BindingPattern{
+			Name: &name,
+		}
+	}
+
+	if p.match(ls.MINUS) {
+		minus := p.previous()
+		if !p.check(ls.NUMBER) {
+			panic(p.error(p.peek(), "expect number after '-' in pattern"))
+		}
+		literal := p.primary().(*Literal)
+		value := literal.Value
+		if value.IsInt() {
+			value = NewIntValue(-*value.IntVal)
+		} else {
+			value = NewFloatValue(-*value.FloatVal)
+		}
+		return &LiteralPattern{
+			Token: &minus,
+			Value: value,
+		}
+	}
+
+	if p.check(ls.NUMBER) || p.check(ls.STRING) || p.check(ls.TRUE) || p.check(ls.FALSE) || p.check(ls.NIL) {
+		literal := p.primary().(*Literal)
+		return &LiteralPattern{
+			Token: literal.Token,
+			Value: literal.Value,
+		}
+	}
+
+	panic(p.error(p.peek(), "expect pattern"))
+}
+
+func (p *Parser) throwStatement() Stmt {
+	keyword := p.previous()
+	value := p.expression()
+	p.consume(ls.SEMICOLON, "expect ';' after thrown value")
+	return &ThrowStmt{
+		Keyword: &keyword,
+		Value:   value,
+	}
+}
+
+// tryStmt → "try" block ( "catch" "(" IDENTIFIER ")" block )? ( "finally" block )?
+func (p *Parser) tryStatement() Stmt {
+	keyword := p.previous()
+	p.consume(ls.LEFT_BRACE, "expect '{' after 'try'")
+	stmt := &TryStmt{
+		Keyword: &keyword,
+		Body:    p.block(),
+	}
+
+	if p.match(ls.CATCH) {
+		p.consume(ls.LEFT_PAREN, "expect '(' after 'catch'")
+		name := p.consume(ls.IDENTIFIER, "expect error variable name")
+		p.consume(ls.RIGHT_PAREN, "expect ')' after error variable")
+		p.consume(ls.LEFT_BRACE, "expect '{' before catch body")
+		stmt.CatchName = &name
+		stmt.Catch = nonNil(p.block())
+	}
+	if p.match(ls.FINALLY) {
+		p.consume(ls.LEFT_BRACE, "expect '{' after 'finally'")
+		stmt.Finally = nonNil(p.block())
+	}
+	if stmt.Catch == nil && stmt.Finally == nil {
+		p.errors = append(p.errors, p.error(keyword, "expect 'catch' or 'finally' after try block"))
+	}
+	return stmt
+}
+
+// nonNil keeps an empty clause distinguishable from a missing one.
+func nonNil(stmts []Stmt) []Stmt {
+	if stmts == nil {
+		return []Stmt{}
+	}
+	return stmts
+}
+
 func (p *Parser) expressionStatement() Stmt {
 	expr := p.ParseExpression()
-	_, err := p.consume(ls.SEMICOLON, "expect ';' after expression")
-	if err != nil {
-		log.Fatal(err)
-	}
+	p.consume(ls.SEMICOLON, "expect ';' after expression")
 	return &ExpressionStmt{
 		Expr: expr,
 	}
@@ -117,18 +741,131 @@ func (p *Parser) expression() Expr {
 	return p.assignment()
 }
 
-// assignment  → IDENTIFIER "=" assignment | equality
+// compoundOperators maps each compound assignment to its binary operator.
+var compoundOperators = map[ls.TokenType]ls.TokenType{
+	ls.PLUS_EQUAL:    ls.PLUS,
+	ls.MINUS_EQUAL:   ls.MINUS,
+	ls.STAR_EQUAL:    ls.STAR,
+	ls.SLASH_EQUAL:   ls.SLASH,
+	ls.PERCENT_EQUAL: ls.PERCENT,
+}
+
+// assignment  → ( call "." IDENTIFIER | call "[" expression "]" | IDENTIFIER ) assignOp assignment
+//
+//	| "[" names "]" "=" assignment
+//	| conditional
 func (p *Parser) assignment() Expr {
-	expr := p.equality()
-	if p.match(ls.EQUAL) {
+	expr := p.conditional()
+	if p.match(ls.EQUAL, ls.PLUS_EQUAL, ls.MINUS_EQUAL, ls.STAR_EQUAL, ls.SLASH_EQUAL, ls.PERCENT_EQUAL) {
+		equals := p.previous()
 		value := p.assignment()
-		if _, ok := expr.(*Variable); ok {
+
+		// A compound assignment carries the binary operator it applies,
+		// positioned at the assignment token.
+		var operator *ls.Token
+		if binaryType, ok := compoundOperators[equals.Type]; ok {
+			op := equals
+			op.Type = binaryType
+			op.Lexeme = equals.Lexeme[:len(equals.Lexeme)-1]
+			operator = &op
+		}
+
+		switch target := expr.(type) {
+		case *Variable:
 			return &Assign{
-				Name: expr.(*Variable).Name,
-				Expr: value,
+				Name:     target.Name,
+				Operator: operator,
+				Expr:     value,
+			}
+		case *Get:
+			return &Set{
+				Object:   target.Object,
+				Name:     target.Name,
+				Operator: operator,
+				Value:    value,
+			}
+		case *Index:
+			return &IndexSet{
+				Object:   target.Object,
+				Bracket:  target.Bracket,
+				Index:    target.Index,
+				Operator: operator,
+				Value:    value,
 			}
+		case *ListLiteral:
+			if targets, ok := destructuringTargets(target); ok && operator == nil {
+				return &DestructureAssign{
+					Bracket: target.LeftBracket,
+					Targets: targets,
+					Value:   value,
+				}
+			}
+		}
+		// The parser isn't confused here, so report without synchronizing.
+		p.errors = append(p.errors, p.error(equals, "invalid assignment target"))
+	}
+	return expr
+}
+
+// destructuringTargets returns the variables of a list literal used as an
+// assignment target, failing if any element is not a plain variable.
+func destructuringTargets(list *ListLiteral) ([]*Variable, bool) {
+	if len(list.Elements) == 0 {
+		return nil, false
+	}
+	targets := make([]*Variable, len(list.Elements))
+	for idx, element := range list.Elements {
+		variable, ok := element.(*Variable)
+		if !ok {
+			return nil, false
+		}
+		targets[idx] = variable
+	}
+	return targets, true
+}
+
+// conditional → logic_or ( "?" expression ":" conditional )?
+func (p *Parser) conditional() Expr {
+	expr := p.or()
+	if p.match(ls.QUESTION) {
+		thenBranch := p.expression()
+		p.consume(ls.COLON, "expect ':' after then branch of conditional expression")
+		elseBranch := p.conditional()
+		return &Conditional{
+			Condition:  expr,
+			ThenBranch: thenBranch,
+			ElseBranch: elseBranch,
+		}
+	}
+	return expr
+}
+
+// logic_or → logic_and ( "or" logic_and )*
+func (p *Parser) or() Expr {
+	expr := p.and()
+	for p.match(ls.OR) {
+		operator := p.previous()
+		right := p.and()
+		expr = &Logical{
+			Left:     expr,
+			Operator: &operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+// logic_and → equality ( "and" equality )*
+func (p *Parser) and() Expr {
+	expr := p.equality()
+	for p.match(ls.AND) {
+		operator := p.previous()
+		right := p.equality()
+		expr = &Logical{
+			Left:     expr,
+			Operator: &operator,
+			Right:    right,
 		}
-		panic("Invalid assignment")
 	}
 	return expr
 }
@@ -149,10 +886,85 @@ func (p *Parser) equality() Expr {
 	return expr
 }
 
-// comparison  → term ( ( ">" | ">=" | "<" | "<=" ) term )*
+// comparison  → range ( ( ">" | ">=" | "<" | "<=" | "in" ) range )*
 func (p *Parser) comparison() Expr {
+	expr := p.rangeExpr()
+	for p.match(ls.GREATER, ls.GREATER_EQUAL, ls.LESS, ls.LESS_EQUAL, ls.IN) {
+		operator := p.previous()
+		right := p.rangeExpr()
+		expr = &Binary{
+			Left:     expr,
+			Operator: &operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+// range → bit_or ( ".." bit_or )?
+func (p *Parser) rangeExpr() Expr {
+	expr := p.bitOr()
+	if p.match(ls.DOT_DOT) {
+		operator := p.previous()
+		end := p.bitOr()
+		return &RangeExpr{
+			Start:    expr,
+			Operator: &operator,
+			End:      end,
+		}
+	}
+	return expr
+}
+
+// bit_or → bit_xor ( "|" bit_xor )*
+func (p *Parser) bitOr() Expr {
+	expr := p.bitXor()
+	for p.match(ls.PIPE) {
+		operator := p.previous()
+		right := p.bitXor()
+		expr = &Binary{
+			Left:     expr,
+			Operator: &operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+// bit_xor → bit_and ( "^" bit_and )*
+func (p *Parser) bitXor() Expr {
+	expr := p.bitAnd()
+	for p.match(ls.CARET) {
+		operator := p.previous()
+		right := p.bitAnd()
+		expr = &Binary{
+			Left:     expr,
+			Operator: &operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+// bit_and → shift ( "&" shift )*
+func (p *Parser) bitAnd() Expr {
+	expr := p.shift()
+	for p.match(ls.AMPERSAND) {
+		operator := p.previous()
+		right := p.shift()
+		expr = &Binary{
+			Left:     expr,
+			Operator: &operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+// shift → term ( ( "<<" | ">>" ) term )*
+func (p *Parser) shift() Expr {
 	expr := p.term()
-	for p.match(ls.GREATER, ls.GREATER_EQUAL, ls.LESS, ls.LESS_EQUAL) {
+	for p.match(ls.LESS_LESS, ls.GREATER_GREATER) {
 		operator := p.previous()
 		right := p.term()
 		expr = &Binary{
@@ -179,10 +991,10 @@ func (p *Parser) term() Expr {
 	return expr
 }
 
-// factor → unary ( ( "/" | "*" ) unary )*
+// factor → unary ( ( "/" | "~/" | "*" | "%" ) unary )*
 func (p *Parser) factor() Expr {
 	expr := p.unary()
-	for p.match(ls.SLASH, ls.STAR) {
+	for p.match(ls.SLASH, ls.TILDE_SLASH, ls.STAR, ls.PERCENT) {
 		operator := p.previous()
 		right := p.unary()
 		expr = &Binary{
@@ -194,11 +1006,16 @@ func (p *Parser) factor() Expr {
 	return expr
 }
 
-// unary          → ( "!" | "-" ) unary
+// unary          → ( "!" | "-" | "~" | "++" | "--" ) unary
 //
-//	| primary
+//	| power
 func (p *Parser) unary() Expr {
-	if p.match(ls.BANG, ls.MINUS) {
+	if p.match(ls.PLUS_PLUS, ls.MINUS_MINUS) {
+		operator := p.previous()
+		target := p.unary()
+		return p.update(target, operator, true)
+	}
+	if p.match(ls.BANG, ls.MINUS, ls.TILDE) {
 		operator := p.previous()
 		right := p.unary()
 		return &Unary{
@@ -206,12 +1023,132 @@ func (p *Parser) unary() Expr {
 			Right:    right,
 		}
 	}
-	return p.primary()
+	return p.power()
+}
+
+// power → postfix ( "**" unary )?
+//
+// Exponentiation is right-associative and binds tighter than a unary
+// operator on its left, so -2 ** 2 is -(2 ** 2).
+func (p *Parser) power() Expr {
+	expr := p.postfix()
+	if p.match(ls.STAR_STAR) {
+		operator := p.previous()
+		right := p.unary()
+		return &Binary{
+			Left:     expr,
+			Operator: &operator,
+			Right:    right,
+		}
+	}
+	return expr
+}
+
+// postfix → call ( "++" | "--" )?
+func (p *Parser) postfix() Expr {
+	expr := p.call()
+	if p.match(ls.PLUS_PLUS, ls.MINUS_MINUS) {
+		return p.update(expr, p.previous(), false)
+	}
+	return expr
+}
+
+// update builds an increment or decrement of target, which must be
+// something assignable.
+func (p *Parser) update(target Expr, operator ls.Token, prefix bool) Expr {
+	switch target.(type) {
+	case *Variable, *Get, *Index:
+		return &Update{
+			Target:   target,
+			Operator: &operator,
+			Prefix:   prefix,
+		}
+	}
+	p.errors = append(p.errors, p.error(operator, "invalid increment or decrement target"))
+	return target
+}
+
+// call → primary ( "(" arguments? ")" | "." IDENTIFIER | "[" subscript "]" )*
+func (p *Parser) call() Expr {
+	expr := p.primary()
+	for {
+		if p.match(ls.LEFT_PAREN) {
+			expr = p.finishCall(expr)
+		} else if p.match(ls.LEFT_BRACKET) {
+			expr = p.finishSubscript(expr)
+		} else if p.match(ls.DOT) {
+			name := p.consume(ls.IDENTIFIER, "expect property name after '.'")
+			expr = &Get{
+				Object: expr,
+				Name:   &name,
+			}
+		} else {
+			break
+		}
+	}
+	return expr
+}
+
+// subscript → expression | expression? ":" expression?
+func (p *Parser) finishSubscript(object Expr) Expr {
+	defer p.enterBrackets()()
+	var start Expr
+	if !p.check(ls.COLON) {
+		start = p.expression()
+	}
+
+	if p.match(ls.COLON) {
+		var end Expr
+		if !p.check(ls.RIGHT_BRACKET) {
+			end = p.expression()
+		}
+		bracket := p.consume(ls.RIGHT_BRACKET, "expect ']' after slice")
+		return &Slice{
+			Object:  object,
+			Bracket: &bracket,
+			Start:   start,
+			End:     end,
+		}
+	}
+
+	bracket := p.consume(ls.RIGHT_BRACKET, "expect ']' after index")
+	return &Index{
+		Object:  object,
+		Bracket: &bracket,
+		Index:   start,
+	}
+}
+
+// arguments → expression ( "," expression )*
+func (p *Parser) finishCall(callee Expr) Expr {
+	defer p.enterBrackets()()
+	var arguments []Expr
+	if !p.check(ls.RIGHT_PAREN) {
+		for {
+			if len(arguments) >= maxArgs {
+				p.errors = append(p.errors, p.error(p.peek(), fmt.Sprintf("can't have more than %d arguments", maxArgs)))
+			}
+			arguments = append(arguments, p.expression())
+			if !p.match(ls.COMMA) {
+				break
+			}
+		}
+	}
+	paren := p.consume(ls.RIGHT_PAREN, "expect ')' after arguments")
+
+	return &Call{
+		Callee:    callee,
+		Paren:     &paren,
+		Arguments: arguments,
+	}
 }
 
-// primary  → NUMBER | STRING | "true" | "false" | "nil"
+// primary  → NUMBER | STRING | "true" | "false" | "nil" | "this"
 //
 //	| "(" expression ")"
+//	| "[" ( expression ( "," expression )* ","? )? "]"
+//	| "{" ( entry ( "," entry )* ","? )? "}"
+//	| IDENTIFIER | "super" "." IDENTIFIER
 func (p *Parser) primary() Expr {
 	if p.match(ls.NUMBER, ls.STRING, ls.TRUE, ls.FALSE, ls.NIL) {
 		token := p.previous()
@@ -220,36 +1157,187 @@ func (p *Parser) primary() Expr {
 
 		switch token.Type {
 		case ls.NUMBER:
-			value = NewFloatValue(literal.(float64))
+			if intLiteral, ok := literal.(int); ok {
+				value = NewIntValue(intLiteral)
+			} else {
+				value = NewFloatValue(literal.(float64))
+			}
 		case ls.STRING:
 			value = NewStringValue(literal.(string))
 		case ls.TRUE, ls.FALSE:
-			value = NewBoolValue(literal.(bool))
+			value = NewBoolValue(token.Type == ls.TRUE)
 		default:
-			value = nil
+			value = NewNilValue()
 		}
 		return &Literal{
+			Token: &token,
 			Value: value,
 		}
 	}
 
+	if p.match(ls.SUPER) {
+		keyword := p.previous()
+		p.consume(ls.DOT, "expect '.' after 'super'")
+		method := p.consume(ls.IDENTIFIER, "expect superclass method name")
+		return &Super{
+			Keyword: &keyword,
+			Method:  &method,
+		}
+	}
+
+	if p.match(ls.THIS) {
+		keyword := p.previous()
+		return &This{
+			Keyword: &keyword,
+		}
+	}
+
+	if p.match(ls.FUN) {
+		return p.lambda()
+	}
+
+	if p.arrowAhead() {
+		return p.arrowFunction()
+	}
+
 	if p.match(ls.IDENTIFIER) {
+		name := p.previous()
 		return &Variable{
-			Name: p.previous().Lexeme,
+			Name: &name,
 		}
 	}
 
-	var expr Expr
-
 	if p.match(ls.LEFT_PAREN) {
-		expr = p.expression()
-		_, err := p.consume(ls.RIGHT_PAREN, "expect ')' after expression")
-		if err != nil {
-			log.Fatal(err)
+		defer p.enterBrackets()()
+		expr := p.expression()
+		p.consume(ls.RIGHT_PAREN, "expect ')' after expression")
+		return expr
+	}
+
+	if p.match(ls.LEFT_BRACKET) {
+		return p.listLiteral()
+	}
+
+	if p.match(ls.LEFT_BRACE) {
+		return p.mapLiteral()
+	}
+
+	panic(p.error(p.peek(), "expect expression"))
+}
+
+// lambda → "fun" "(" parameters? ")" block
+func (p *Parser) lambda() Expr {
+	defer p.enterBrackets()()
+	keyword := p.previous()
+	p.consume(ls.LEFT_PAREN, "expect '(' after 'fun'")
+	params := p.parameters()
+	p.consume(ls.LEFT_BRACE, "expect '{' before function body")
+	body := p.block()
+
+	return &Lambda{
+		Keyword: &keyword,
+		Function: &FunctionStmt{
+			Params: params,
+			Body:   body,
+		},
+		span: keyword.Span().Merge(p.previous().Span()),
+	}
+}
+
+// arrowAhead reports whether the upcoming tokens are an arrow function's
+// parameter list, which otherwise reads like a variable or a grouping.
+func (p *Parser) arrowAhead() bool {
+	if p.inGuard {
+		return false
+	}
+	idx := p.current
+	if p.tokens[idx].Type == ls.IDENTIFIER {
+		return p.tokens[idx+1].Type == ls.ARROW
+	}
+	if p.tokens[idx].Type != ls.LEFT_PAREN {
+		return false
+	}
+	idx++
+	for p.tokens[idx].Type == ls.IDENTIFIER || p.tokens[idx].Type == ls.COMMA {
+		idx++
+	}
+	return p.tokens[idx].Type == ls.RIGHT_PAREN && p.tokens[idx+1].Type == ls.ARROW
+}
+
+// lambda → ( IDENTIFIER | "(" parameters? ")" ) "=>" ( block | expression )
+//
+// An expression body is sugar for a block returning it.
+func (p *Parser) arrowFunction() Expr {
+	start := p.peek()
+	var params []*ls.Token
+	if p.match(ls.IDENTIFIER) {
+		param := p.previous()
+		params = append(params, &param)
+	} else {
+		p.consume(ls.LEFT_PAREN, "expect '(' before parameters")
+		params = p.parameters()
+	}
+	arrow := p.consume(ls.ARROW, "expect '=>' after parameters")
+
+	var body []Stmt
+	var end ls.Span
+	if p.match(ls.LEFT_BRACE) {
+		body = p.block()
+		end = p.previous().Span()
+	} else {
+		value := p.expression()
+		body = []Stmt{&ReturnStmt{Keyword: &arrow, Value: value}}
+		end = value.Span()
+	}
+
+	return &Lambda{
+		Keyword: &arrow,
+		Function: &FunctionStmt{
+			Params: params,
+			Body:   body,
+		},
+		span: start.Span().Merge(end),
+	}
+}
+
+func (p *Parser) listLiteral() Expr {
+	defer p.enterBrackets()()
+	leftBracket := p.previous()
+	var elements []Expr
+	for !p.check(ls.RIGHT_BRACKET) {
+		elements = append(elements, p.expression())
+		if !p.match(ls.COMMA) {
+			break
 		}
 	}
+	rightBracket := p.consume(ls.RIGHT_BRACKET, "expect ']' after list elements")
+	return &ListLiteral{
+		LeftBracket:  &leftBracket,
+		Elements:     elements,
+		RightBracket: &rightBracket,
+	}
+}
 
-	return expr
+// entry → expression ":" expression
+func (p *Parser) mapLiteral() Expr {
+	defer p.enterBrackets()()
+	leftBrace := p.previous()
+	var keys, values []Expr
+	for !p.check(ls.RIGHT_BRACE) {
+		keys = append(keys, p.expression())
+		p.consume(ls.COLON, "expect ':' after map key")
+		values = append(values, p.expression())
+		if !p.match(ls.COMMA) {
+			break
+		}
+	}
+	rightBrace := p.consume(ls.RIGHT_BRACE, "expect '}' after map entries")
+	return &MapLiteral{
+		LeftBrace:  &leftBrace,
+		Keys:       keys,
+		Values:     values,
+		RightBrace: &rightBrace,
+	}
 }
 
 // utilities
@@ -278,6 +1366,20 @@ func (p *Parser) check(tokenType ls.TokenType) bool {
 	return p.peek().Type == tokenType
 }
 
+// checkNext looks one token past the current one.
+func (p *Parser) checkNext(tokenType ls.TokenType) bool {
+	return p.checkAt(1, tokenType)
+}
+
+// checkAt looks offset tokens past the current one without consuming.
+func (p *Parser) checkAt(offset int, tokenType ls.TokenType) bool {
+	idx := p.current + offset
+	if idx >= len(p.tokens) {
+		return false
+	}
+	return p.tokens[idx].Type == tokenType
+}
+
 func (p *Parser) isAtEnd() bool {
 	return p.peek().Type == ls.EOF
 }
@@ -290,9 +1392,34 @@ func (p *Parser) previous() ls.Token {
 	return p.tokens[p.current-1]
 }
 
-func (p *Parser) consume(tokenType ls.TokenType, message string) (ls.Token, error) {
+// consume advances past a token of the given type, or panics with a
+// ParseError for declaration to recover from.
+func (p *Parser) consume(tokenType ls.TokenType, message string) ls.Token {
 	if p.check(tokenType) {
-		return p.advance(), nil
+		return p.advance()
+	}
+	panic(p.error(p.peek(), message))
+}
+
+func (p *Parser) error(token ls.Token, message string) ParseError {
+	return ParseError{
+		Token:   token,
+		Message: message,
+	}
+}
+
+// synchronize discards tokens until it reaches a likely statement boundary.
+func (p *Parser) synchronize() {
+	p.advance()
+	for !p.isAtEnd() {
+		if p.previous().Type == ls.SEMICOLON {
+			return
+		}
+		switch p.peek().Type {
+		case ls.CLASS, ls.ENUM, ls.FUN, ls.VAR, ls.CONST, ls.IMPORT, ls.EXPORT, ls.FOR, ls.IF, ls.WHILE, ls.PRINT, ls.RETURN, ls.THROW, ls.TRY,
+			ls.MATCH:
+			return
+		}
+		p.advance()
 	}
-	return ls.Token{}, errors.New(message)
 }
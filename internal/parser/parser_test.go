@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"testing"
+
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+func parse(t *testing.T, source string) ([]Stmt, []ParseError) {
+	t.Helper()
+	tokens, scanErrors := ls.NewLexScanner(source).ScanTokens()
+	if len(scanErrors) > 0 {
+		t.Fatalf("%q: unexpected scan errors %v", source, scanErrors)
+	}
+	return NewParser(tokens).Parse()
+}
+
+// parseTest is a source that must parse to one statement printing as want.
+type parseTest struct {
+	source string
+	want   string
+}
+
+func runParseTests(t *testing.T, tests []parseTest) {
+	t.Helper()
+	for _, test := range tests {
+		stmts, errs := parse(t, test.source)
+		if len(errs) > 0 {
+			t.Errorf("%q: unexpected errors %v", test.source, errs)
+			continue
+		}
+		if len(stmts) != 1 || stmts[0].String() != test.want {
+			t.Errorf("%q: got %v, want [%s]", test.source, stmts, test.want)
+		}
+	}
+}
+
+// recoveryTest is a source with syntax errors, the number of statements
+// that still parse, and the expected error messages in order.
+type recoveryTest struct {
+	source    string
+	wantStmts int
+	wantErrs  []string
+}
+
+func runRecoveryTests(t *testing.T, tests []recoveryTest) {
+	t.Helper()
+	for _, test := range tests {
+		stmts, errs := parse(t, test.source)
+		if len(stmts) != test.wantStmts {
+			t.Errorf("%q: got %d statements %v, want %d", test.source, len(stmts), stmts, test.wantStmts)
+		}
+		if len(errs) != len(test.wantErrs) {
+			t.Errorf("%q: got errors %v, want %v", test.source, errs, test.wantErrs)
+			continue
+		}
+		for idx, err := range errs {
+			if err.Message != test.wantErrs[idx] {
+				t.Errorf("%q: error %d is %q, want %q", test.source, idx, err.Message, test.wantErrs[idx])
+			}
+		}
+	}
+}
+
+func TestParsePrecedence(t *testing.T) {
+	runParseTests(t, []parseTest{
+		{"1 + 2 * 3;", "ExpressionStmt: (1 + (2 * 3))"},
+		{"a == b < c;", "ExpressionStmt: (a == (b < c))"},
+		{"!a == b;", "ExpressionStmt: ((! a) == b)"},
+		{"-x ** 2;", "ExpressionStmt: (- (x ** 2))"},
+		{"2 ** 3 ** 2;", "ExpressionStmt: (2 ** (3 ** 2))"},
+		{"a | b & c << 1;", "ExpressionStmt: (a | (b & (c << 1)))"},
+		{"a = b ? 1 : 2;", "ExpressionStmt: a = (b ? 1 : 2)"},
+		{"a ? b : c ? d : e;", "ExpressionStmt: (a ? b : (c ? d : e))"},
+		{"i += 1;", "ExpressionStmt: i += 1"},
+		{"f(1)[2].c;", "ExpressionStmt: f(1)[2].c"},
+		{"x in 0..10;", "ExpressionStmt: (x in (0..10))"},
+		{"[a, b] = [b, a];", "ExpressionStmt: (= [a, b] [b, a])"},
+	})
+}
+
+func TestParseGuardLambda(t *testing.T) {
+	runParseTests(t, []parseTest{
+		{"match (xs) { case [a, b] if len(filter(xs, x => x > 1)) > 0 => print a; }",
+			"MatchStmt: (xs) [case [a, b] if (len(filter(xs, fun (x))) > 0) => PrintStmt: a]"},
+		{"match (x) { case 1 if [y => y][0](x) => print 1; case _ if {1: (y) => y}[1](x) => print 2; }",
+			"MatchStmt: (x) [case 1 if [fun (y)][0](x) => PrintStmt: 1 case _ if {1: fun (y)}[1](x) => PrintStmt: 2]"},
+		{"match (x) { case y if y => print y; }", "MatchStmt: (x) [case y if y => PrintStmt: y]"},
+	})
+}
+
+func TestParseErrorRecovery(t *testing.T) {
+	runRecoveryTests(t, []recoveryTest{
+		{"print 1", 0, []string{"expect ';' after expression"}},
+		{"var = 1; print 2;", 1, []string{"expect variable name"}},
+		{"var x = ; print 1; var y = 2", 1, []string{"expect expression", "expect ';' after variable declaration"}},
+		{"1 = 2; print 3;", 2, []string{"invalid assignment target"}},
+		{"print (1; print 2; print )", 1, []string{"expect ')' after expression", "expect expression"}},
+		{"1++; print 2;", 2, []string{"invalid increment or decrement target"}},
+		{"try { } print 1;", 2, []string{"expect 'catch' or 'finally' after try block"}},
+		{"match x { } print 1;", 1, []string{"expect '(' after 'match'"}},
+		{"[a, 1] = [1, 2]; print 3;", 2, []string{"invalid assignment target"}},
+		{"fun f( { } print 1;", 1, []string{"expect parameter name"}},
+		{"fun f( { } class C { } print 1;", 2, []string{"expect parameter name"}},
+	})
+}
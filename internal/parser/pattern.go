@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+// Pattern is the left-hand side of a match arm. Patterns are inspected
+// with a type switch rather than a visitor since matching needs both the
+// subject value and the scope bindings go into.
+type Pattern interface {
+	String() string
+}
+
+// LiteralPattern matches values equal to Value.
+type LiteralPattern struct {
+	Token *ls.Token
+	Value *Value
+}
+
+func (lp *LiteralPattern) String() string {
+	return lp.Value.String()
+}
+
+// BindingPattern matches anything and binds it to Name.
+type BindingPattern struct {
+	Name *ls.Token
+}
+
+func (bp *BindingPattern) String() string {
+	return bp.Name.Lexeme
+}
+
+// WildcardPattern, written "_", matches anything without binding it.
+type WildcardPattern struct {
+	Token *ls.Token
+}
+
+func (wp *WildcardPattern) String() string {
+	return "_"
+}
+
+// MemberPattern, written "Name.member", matches values equal to that
+// property of the variable Name, such as an enum member.
+type MemberPattern struct {
+	Value *Get
+}
+
+func (mp *MemberPattern) String() string {
+	return mp.Value.Object.String() + "." + mp.Value.Name.Lexeme
+}
+
+// ListPattern matches a list of exactly len(Elements) elements whose
+// elements match pairwise.
+type ListPattern struct {
+	Bracket  *ls.Token
+	Elements []Pattern
+}
+
+func (lp *ListPattern) String() string {
+	elements := make([]string, len(lp.Elements))
+	for idx, element := range lp.Elements {
+		elements[idx] = element.String()
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+// AlternativePattern matches if any of its Alternatives does.
+type AlternativePattern struct {
+	Alternatives []Pattern
+}
+
+func (ap *AlternativePattern) String() string {
+	alternatives := make([]string, len(ap.Alternatives))
+	for idx, alternative := range ap.Alternatives {
+		alternatives[idx] = alternative.String()
+	}
+	return strings.Join(alternatives, " | ")
+}
+
+// MatchArm runs Body when Pattern matches and Guard, if any, is truthy.
+type MatchArm struct {
+	Keyword *ls.Token
+	Pattern Pattern
+	Guard   Expr
+	Body    Stmt
+}
+
+func (ma *MatchArm) String() string {
+	if ma.Guard != nil {
+		return fmt.Sprintf("case %s if %s => %s", ma.Pattern, ma.Guard, ma.Body)
+	}
+	return fmt.Sprintf("case %s => %s", ma.Pattern, ma.Body)
+}
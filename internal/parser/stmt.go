@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 
 	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
 )
@@ -12,6 +13,20 @@ const (
 	EXPRESSION_STMT StmtType = iota
 	PRINT_STMT
 	VAR_STMT
+	BLOCK_STMT
+	IF_STMT
+	WHILE_STMT
+	FUNCTION_STMT
+	RETURN_STMT
+	CLASS_STMT
+	FOR_IN_STMT
+	THROW_STMT
+	TRY_STMT
+	MATCH_STMT
+	DESTRUCTURE_STMT
+	IMPORT_STMT
+	EXPORT_STMT
+	ENUM_STMT
 )
 
 type Stmt interface {
@@ -24,6 +39,20 @@ type StmtVisitor interface {
 	VisitExpressionStmt(stmt *ExpressionStmt) *Value
 	VisitPrintStmt(stmt *PrintStmt) *Value
 	VisitVarStmt(stmt *VarStmt) *Value
+	VisitBlockStmt(stmt *BlockStmt) *Value
+	VisitIfStmt(stmt *IfStmt) *Value
+	VisitWhileStmt(stmt *WhileStmt) *Value
+	VisitFunctionStmt(stmt *FunctionStmt) *Value
+	VisitReturnStmt(stmt *ReturnStmt) *Value
+	VisitClassStmt(stmt *ClassStmt) *Value
+	VisitForInStmt(stmt *ForInStmt) *Value
+	VisitThrowStmt(stmt *ThrowStmt) *Value
+	VisitTryStmt(stmt *TryStmt) *Value
+	VisitMatchStmt(stmt *MatchStmt) *Value
+	VisitDestructureStmt(stmt *DestructureStmt) *Value
+	VisitImportStmt(stmt *ImportStmt) *Value
+	VisitExportStmt(stmt *ExportStmt) *Value
+	VisitEnumStmt(stmt *EnumStmt) *Value
 }
 
 type ExpressionStmt struct {
@@ -58,9 +87,12 @@ func (ps *PrintStmt) Accept(visitor StmtVisitor) *Value {
 	return visitor.VisitPrintStmt(ps)
 }
 
+// VarStmt declares a variable, or a constant when Const is set. Constants
+// always have an initializer.
 type VarStmt struct {
-	Name *ls.Token
-	Expr Expr
+	Name  *ls.Token
+	Expr  Expr
+	Const bool
 }
 
 func (vs *VarStmt) Type() StmtType {
@@ -72,9 +104,342 @@ func (vs *VarStmt) String() string {
 	if vs.Expr != nil {
 		exprStr = vs.Expr.String()
 	}
+	if vs.Const {
+		return fmt.Sprintf("VarStmt: const %s = %s", vs.Name.Lexeme, exprStr)
+	}
 	return fmt.Sprintf("VarStmt: %s = %s", vs.Name.Lexeme, exprStr)
 }
 
 func (vs *VarStmt) Accept(visitor StmtVisitor) *Value {
 	return visitor.VisitVarStmt(vs)
 }
+
+type BlockStmt struct {
+	Stmts []Stmt
+}
+
+func (bs *BlockStmt) Type() StmtType {
+	return BLOCK_STMT
+}
+
+func (bs *BlockStmt) String() string {
+	parts := make([]string, len(bs.Stmts))
+	for idx, stmt := range bs.Stmts {
+		parts[idx] = stmt.String()
+	}
+	return fmt.Sprintf("BlockStmt: { %s }", strings.Join(parts, "; "))
+}
+
+func (bs *BlockStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitBlockStmt(bs)
+}
+
+type IfStmt struct {
+	Condition  Expr
+	ThenBranch Stmt
+	ElseBranch Stmt
+}
+
+func (is *IfStmt) Type() StmtType {
+	return IF_STMT
+}
+
+func (is *IfStmt) String() string {
+	if is.ElseBranch == nil {
+		return fmt.Sprintf("IfStmt: (%s) %s", is.Condition, is.ThenBranch)
+	}
+	return fmt.Sprintf("IfStmt: (%s) %s else %s", is.Condition, is.ThenBranch, is.ElseBranch)
+}
+
+func (is *IfStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitIfStmt(is)
+}
+
+type WhileStmt struct {
+	Condition Expr
+	Body      Stmt
+}
+
+func (ws *WhileStmt) Type() StmtType {
+	return WHILE_STMT
+}
+
+func (ws *WhileStmt) String() string {
+	return fmt.Sprintf("WhileStmt: (%s) %s", ws.Condition, ws.Body)
+}
+
+func (ws *WhileStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitWhileStmt(ws)
+}
+
+// FunctionStmt declares a named function. Anonymous functions reuse it
+// through Lambda with a nil Name.
+type FunctionStmt struct {
+	Name   *ls.Token
+	Params []*ls.Token
+	Body   []Stmt
+}
+
+func (fs *FunctionStmt) Type() StmtType {
+	return FUNCTION_STMT
+}
+
+func (fs *FunctionStmt) String() string {
+	params := make([]string, len(fs.Params))
+	for idx, param := range fs.Params {
+		params[idx] = param.Lexeme
+	}
+	name := "<anonymous>"
+	if fs.Name != nil {
+		name = fs.Name.Lexeme
+	}
+	return fmt.Sprintf("FunctionStmt: %s(%s)", name, strings.Join(params, ", "))
+}
+
+func (fs *FunctionStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitFunctionStmt(fs)
+}
+
+type ReturnStmt struct {
+	Keyword *ls.Token
+	Value   Expr
+}
+
+func (rs *ReturnStmt) Type() StmtType {
+	return RETURN_STMT
+}
+
+func (rs *ReturnStmt) String() string {
+	if rs.Value == nil {
+		return "ReturnStmt"
+	}
+	return fmt.Sprintf("ReturnStmt: %s", rs.Value)
+}
+
+func (rs *ReturnStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitReturnStmt(rs)
+}
+
+type ClassStmt struct {
+	Name       *ls.Token
+	Superclass *Variable
+	Methods    []*FunctionStmt
+}
+
+func (cs *ClassStmt) Type() StmtType {
+	return CLASS_STMT
+}
+
+func (cs *ClassStmt) String() string {
+	methods := make([]string, len(cs.Methods))
+	for idx, method := range cs.Methods {
+		methods[idx] = method.Name.Lexeme
+	}
+	if cs.Superclass == nil {
+		return fmt.Sprintf("ClassStmt: %s { %s }", cs.Name.Lexeme, strings.Join(methods, ", "))
+	}
+	return fmt.Sprintf("ClassStmt: %s < %s { %s }", cs.Name.Lexeme, cs.Superclass, strings.Join(methods, ", "))
+}
+
+func (cs *ClassStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitClassStmt(cs)
+}
+
+// ForInStmt runs Body once per element of Iterable, with Name bound to
+// the element in a fresh scope each time.
+type ForInStmt struct {
+	Keyword  *ls.Token
+	Name     *ls.Token
+	Iterable Expr
+	Body     Stmt
+}
+
+func (fs *ForInStmt) Type() StmtType {
+	return FOR_IN_STMT
+}
+
+func (fs *ForInStmt) String() string {
+	return fmt.Sprintf("ForInStmt: (%s in %s) %s", fs.Name.Lexeme, fs.Iterable, fs.Body)
+}
+
+func (fs *ForInStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitForInStmt(fs)
+}
+
+type ThrowStmt struct {
+	Keyword *ls.Token
+	Value   Expr
+}
+
+func (ts *ThrowStmt) Type() StmtType {
+	return THROW_STMT
+}
+
+func (ts *ThrowStmt) String() string {
+	return fmt.Sprintf("ThrowStmt: %s", ts.Value)
+}
+
+func (ts *ThrowStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitThrowStmt(ts)
+}
+
+// TryStmt runs Body, handing any error raised in it to the catch clause
+// when there is one. Finally runs last however Body or Catch exit. At
+// least one of Catch and Finally is non-nil.
+type TryStmt struct {
+	Keyword   *ls.Token
+	Body      []Stmt
+	CatchName *ls.Token
+	Catch     []Stmt
+	Finally   []Stmt
+}
+
+func (ts *TryStmt) Type() StmtType {
+	return TRY_STMT
+}
+
+func (ts *TryStmt) String() string {
+	s := fmt.Sprintf("TryStmt: %v", ts.Body)
+	if ts.Catch != nil {
+		s += fmt.Sprintf(" catch (%s) %v", ts.CatchName.Lexeme, ts.Catch)
+	}
+	if ts.Finally != nil {
+		s += fmt.Sprintf(" finally %v", ts.Finally)
+	}
+	return s
+}
+
+func (ts *TryStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitTryStmt(ts)
+}
+
+// MatchStmt runs the first arm whose pattern matches Subject.
+type MatchStmt struct {
+	Keyword *ls.Token
+	Subject Expr
+	Arms    []*MatchArm
+}
+
+func (ms *MatchStmt) Type() StmtType {
+	return MATCH_STMT
+}
+
+func (ms *MatchStmt) String() string {
+	return fmt.Sprintf("MatchStmt: (%s) %v", ms.Subject, ms.Arms)
+}
+
+func (ms *MatchStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitMatchStmt(ms)
+}
+
+// DestructureStmt declares Names from the elements of a list, in order,
+// or when Record is set from the like-named keys of a map or properties
+// of an object.
+type DestructureStmt struct {
+	Open   *ls.Token
+	Names  []*ls.Token
+	Record bool
+	Expr   Expr
+	Const  bool
+}
+
+func (ds *DestructureStmt) Type() StmtType {
+	return DESTRUCTURE_STMT
+}
+
+func (ds *DestructureStmt) String() string {
+	names := make([]string, len(ds.Names))
+	for idx, name := range ds.Names {
+		names[idx] = name.Lexeme
+	}
+	pattern := "[" + strings.Join(names, ", ") + "]"
+	if ds.Record {
+		pattern = "{" + strings.Join(names, ", ") + "}"
+	}
+	if ds.Const {
+		return fmt.Sprintf("DestructureStmt: const %s = %s", pattern, ds.Expr)
+	}
+	return fmt.Sprintf("DestructureStmt: %s = %s", pattern, ds.Expr)
+}
+
+func (ds *DestructureStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitDestructureStmt(ds)
+}
+
+// ImportStmt binds the module loaded from Path to Alias.
+type ImportStmt struct {
+	Keyword *ls.Token
+	Path    *ls.Token
+	Alias   *ls.Token
+}
+
+func (is *ImportStmt) Type() StmtType {
+	return IMPORT_STMT
+}
+
+func (is *ImportStmt) String() string {
+	return fmt.Sprintf("ImportStmt: %s as %s", is.Path.Lexeme, is.Alias.Lexeme)
+}
+
+func (is *ImportStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitImportStmt(is)
+}
+
+// ExportStmt makes the names Declaration declares visible to importers.
+type ExportStmt struct {
+	Keyword     *ls.Token
+	Declaration Stmt
+}
+
+func (es *ExportStmt) Type() StmtType {
+	return EXPORT_STMT
+}
+
+func (es *ExportStmt) String() string {
+	return fmt.Sprintf("ExportStmt: %s", es.Declaration)
+}
+
+func (es *ExportStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitExportStmt(es)
+}
+
+// DeclaredNames returns the names stmt declares in its scope, or nil if
+// it is not a declaration.
+func DeclaredNames(stmt Stmt) []*ls.Token {
+	switch stmt := stmt.(type) {
+	case *VarStmt:
+		return []*ls.Token{stmt.Name}
+	case *DestructureStmt:
+		return stmt.Names
+	case *FunctionStmt:
+		return []*ls.Token{stmt.Name}
+	case *ClassStmt:
+		return []*ls.Token{stmt.Name}
+	case *EnumStmt:
+		return []*ls.Token{stmt.Name}
+	}
+	return nil
+}
+
+// EnumStmt declares Name as a constant holding one distinct value per
+// member, in declaration order.
+type EnumStmt struct {
+	Name    *ls.Token
+	Members []*ls.Token
+}
+
+func (es *EnumStmt) Type() StmtType {
+	return ENUM_STMT
+}
+
+func (es *EnumStmt) String() string {
+	members := make([]string, len(es.Members))
+	for idx, member := range es.Members {
+		members[idx] = member.Lexeme
+	}
+	return fmt.Sprintf("EnumStmt: %s { %s }", es.Name.Lexeme, strings.Join(members, ", "))
+}
+
+func (es *EnumStmt) Accept(visitor StmtVisitor) *Value {
+	return visitor.VisitEnumStmt(es)
+}
@@ -0,0 +1,179 @@
+package resolver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+// checkMatch warns when the arms of stmt test for members of a single
+// enum, none of them is a catch-all, and some member is not covered by an
+// unguarded arm.
+func (r *Resolver) checkMatch(stmt *parser.MatchStmt) {
+	enum := ""
+	covered := make(map[string]bool)
+	for _, arm := range stmt.Arms {
+		switch arm.Pattern.(type) {
+		case *parser.WildcardPattern, *parser.BindingPattern:
+			if arm.Guard == nil {
+				return
+			}
+			continue
+		}
+		name, members, ok := patternMembers(arm.Pattern)
+		if !ok || enum != "" && name != enum {
+			return
+		}
+		enum = name
+		if arm.Guard == nil {
+			for _, member := range members {
+				covered[member] = true
+			}
+		}
+	}
+	r.warnMissing(stmt.Keyword, "match", enum, covered)
+}
+
+// patternMembers returns the enum and members a pattern made of
+// "Enum.Member" alternatives matches.
+func patternMembers(pattern parser.Pattern) (string, []string, bool) {
+	switch pattern := pattern.(type) {
+	case *parser.MemberPattern:
+		enum, ok := pattern.Value.Object.(*parser.Variable)
+		if !ok {
+			return "", nil, false
+		}
+		return enum.Name.Lexeme, []string{pattern.Value.Name.Lexeme}, true
+	case *parser.AlternativePattern:
+		var enum string
+		var members []string
+		for _, alternative := range pattern.Alternatives {
+			name, more, ok := patternMembers(alternative)
+			if !ok || enum != "" && name != enum {
+				return "", nil, false
+			}
+			enum = name
+			members = append(members, more...)
+		}
+		return enum, members, true
+	}
+	return "", nil, false
+}
+
+// checkIfChain warns when an if/else-if chain of two or more branches
+// compares one variable against members of an enum, has no final else,
+// and leaves some member out. Each if in the chain is checked only as
+// part of the longest chain it belongs to.
+func (r *Resolver) checkIfChain(stmt *parser.IfStmt) {
+	if r.checkedChains[stmt] {
+		return
+	}
+
+	var subject *ls.Token
+	enum := ""
+	covered := make(map[string]bool)
+	branches := 0
+	for current := stmt; ; {
+		name, enumName, members, ok := r.enumComparison(current.Condition)
+		if !ok || subject != nil && (name.Lexeme != subject.Lexeme || enumName != enum) {
+			return
+		}
+		r.checkedChains[current] = true
+		if subject == nil {
+			subject, enum = name, enumName
+		}
+		for _, member := range members {
+			covered[member] = true
+		}
+		branches++
+
+		next, ok := current.ElseBranch.(*parser.IfStmt)
+		if !ok {
+			if current.ElseBranch != nil {
+				return
+			}
+			break
+		}
+		current = next
+	}
+	if branches >= 2 {
+		r.warnMissing(subject, "if chain", enum, covered)
+	}
+}
+
+// enumComparison recognizes conditions of the form "x == Enum.Member",
+// optionally joined with "or", returning x, the enum and the members.
+func (r *Resolver) enumComparison(condition parser.Expr) (*ls.Token, string, []string, bool) {
+	switch condition := condition.(type) {
+	case *parser.Logical:
+		if condition.Operator.Type != ls.OR {
+			return nil, "", nil, false
+		}
+		leftName, leftEnum, leftMembers, ok := r.enumComparison(condition.Left)
+		if !ok {
+			return nil, "", nil, false
+		}
+		rightName, rightEnum, rightMembers, ok := r.enumComparison(condition.Right)
+		if !ok || leftName.Lexeme != rightName.Lexeme || leftEnum != rightEnum {
+			return nil, "", nil, false
+		}
+		return leftName, leftEnum, append(leftMembers, rightMembers...), true
+	case *parser.Binary:
+		if condition.Operator.Type != ls.EQUAL_EQUAL {
+			return nil, "", nil, false
+		}
+		subject, ok := condition.Left.(*parser.Variable)
+		member, isMember := condition.Right.(*parser.Get)
+		if !ok || !isMember {
+			subject, ok = condition.Right.(*parser.Variable)
+			member, isMember = condition.Left.(*parser.Get)
+		}
+		if !ok || !isMember {
+			return nil, "", nil, false
+		}
+		enum, ok := member.Object.(*parser.Variable)
+		if !ok {
+			return nil, "", nil, false
+		}
+		if _, declared := r.lookUpEnum(enum.Name.Lexeme); !declared {
+			return nil, "", nil, false
+		}
+		return subject.Name, enum.Name.Lexeme, []string{member.Name.Lexeme}, true
+	}
+	return nil, "", nil, false
+}
+
+// checkEnumMember reports "Enum.Member" at the member if Enum is an enum
+// declared in this file that has no such member. Match patterns and enum
+// comparisons both read members this way.
+func (r *Resolver) checkEnumMember(expr *parser.Get) {
+	enum, ok := expr.Object.(*parser.Variable)
+	if !ok {
+		return
+	}
+	members, ok := r.lookUpEnum(enum.Name.Lexeme)
+	if ok && !slices.Contains(members, expr.Name.Lexeme) {
+		r.error(expr.Name, fmt.Sprintf("enum '%s' has no member '%s'", enum.Name.Lexeme, expr.Name.Lexeme))
+	}
+}
+
+// warnMissing warns at token if covered lacks any member of enum. Names
+// that don't refer to an enum declared in this file are skipped.
+func (r *Resolver) warnMissing(token *ls.Token, construct string, enum string, covered map[string]bool) {
+	members, ok := r.lookUpEnum(enum)
+	if !ok {
+		return
+	}
+	var missing []string
+	for _, member := range members {
+		if !covered[member] {
+			missing = append(missing, member)
+		}
+	}
+	if len(missing) > 0 {
+		r.warn(token, fmt.Sprintf("%s over enum '%s' doesn't handle %s", construct, enum, strings.Join(missing, ", ")))
+	}
+}
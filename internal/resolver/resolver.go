@@ -0,0 +1,616 @@
+package resolver
+
+import (
+	"fmt"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/interpreter"
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+type functionType int
+
+const (
+	functionNone functionType = iota
+	functionFunction
+	functionMethod
+	functionInitializer
+)
+
+type classType int
+
+const (
+	classNone classType = iota
+	classClass
+	classSubclass
+)
+
+// ResolveError describes a static error found while resolving.
+type ResolveError struct {
+	Token   ls.Token
+	Message string
+}
+
+func (e ResolveError) Error() string {
+	return fmt.Sprintf("[line %d:%d] Error at '%s': %s", e.Token.Line, e.Token.Column, e.Token.Lexeme, e.Message)
+}
+
+// ResolveWarning describes a likely mistake that doesn't stop the program
+// from running.
+type ResolveWarning struct {
+	Token   ls.Token
+	Message string
+}
+
+func (w ResolveWarning) String() string {
+	return fmt.Sprintf("[line %d:%d] Warning at '%s': %s", w.Token.Line, w.Token.Column, w.Token.Lexeme, w.Message)
+}
+
+// Resolver walks the AST once before execution, telling the interpreter
+// how many scopes out each local variable reference binds. Names that are
+// not found in any local scope are left to the globals.
+type Resolver struct {
+	interpreter     *interpreter.Interpreter
+	scopes          []map[string]bool // false until the variable's initializer has run
+	constants       []map[string]bool // names declared with const, parallel to scopes
+	globalConstants map[string]bool
+	currentFunction functionType
+	currentClass    classType
+	enums           []map[string][]string // members of the enums declared in each scope, parallel to scopes
+	globalEnums     map[string][]string
+	checkedChains   map[*parser.IfStmt]bool
+	errors          []ResolveError
+	warnings        []ResolveWarning
+}
+
+func NewResolver(interpreter *interpreter.Interpreter) *Resolver {
+	return &Resolver{
+		interpreter:     interpreter,
+		scopes:          make([]map[string]bool, 0),
+		globalConstants: make(map[string]bool),
+		currentFunction: functionNone,
+		currentClass:    classNone,
+		globalEnums:     make(map[string][]string),
+		checkedChains:   make(map[*parser.IfStmt]bool),
+		errors:          make([]ResolveError, 0),
+	}
+}
+
+// Resolve resolves stmts and returns every static error found in them.
+func (r *Resolver) Resolve(stmts []parser.Stmt) []ResolveError {
+	r.resolveStmts(stmts)
+	return r.errors
+}
+
+// Warnings returns the warnings found by Resolve.
+func (r *Resolver) Warnings() []ResolveWarning {
+	return r.warnings
+}
+
+// Implement StmtVisitor
+func (r *Resolver) VisitExpressionStmt(stmt *parser.ExpressionStmt) *parser.Value {
+	r.resolveExpr(stmt.Expr)
+	return nil
+}
+
+func (r *Resolver) VisitPrintStmt(stmt *parser.PrintStmt) *parser.Value {
+	r.resolveExpr(stmt.Expr)
+	return nil
+}
+
+func (r *Resolver) VisitVarStmt(stmt *parser.VarStmt) *parser.Value {
+	r.declare(stmt.Name)
+	if stmt.Expr != nil {
+		r.resolveExpr(stmt.Expr)
+	}
+	r.define(stmt.Name)
+	r.markConstant(stmt.Name, stmt.Const)
+	return nil
+}
+
+func (r *Resolver) VisitDestructureStmt(stmt *parser.DestructureStmt) *parser.Value {
+	for _, name := range stmt.Names {
+		r.declare(name)
+	}
+	r.resolveExpr(stmt.Expr)
+	for _, name := range stmt.Names {
+		r.define(name)
+		r.markConstant(name, stmt.Const)
+	}
+	return nil
+}
+
+func (r *Resolver) VisitBlockStmt(stmt *parser.BlockStmt) *parser.Value {
+	r.beginScope()
+	r.resolveStmts(stmt.Stmts)
+	r.endScope()
+	return nil
+}
+
+func (r *Resolver) VisitIfStmt(stmt *parser.IfStmt) *parser.Value {
+	r.checkIfChain(stmt)
+	r.resolveExpr(stmt.Condition)
+	r.resolveStmt(stmt.ThenBranch)
+	if stmt.ElseBranch != nil {
+		r.resolveStmt(stmt.ElseBranch)
+	}
+	return nil
+}
+
+func (r *Resolver) VisitWhileStmt(stmt *parser.WhileStmt) *parser.Value {
+	r.resolveExpr(stmt.Condition)
+	r.resolveStmt(stmt.Body)
+	return nil
+}
+
+func (r *Resolver) VisitFunctionStmt(stmt *parser.FunctionStmt) *parser.Value {
+	// Define eagerly so the function can refer to itself recursively.
+	r.declare(stmt.Name)
+	r.define(stmt.Name)
+	r.resolveFunction(stmt, functionFunction)
+	return nil
+}
+
+func (r *Resolver) VisitReturnStmt(stmt *parser.ReturnStmt) *parser.Value {
+	if r.currentFunction == functionNone {
+		r.error(stmt.Keyword, "can't return from top-level code")
+	}
+	if stmt.Value != nil {
+		if r.currentFunction == functionInitializer {
+			r.error(stmt.Keyword, "can't return a value from an initializer")
+		}
+		r.resolveExpr(stmt.Value)
+	}
+	return nil
+}
+
+func (r *Resolver) VisitClassStmt(stmt *parser.ClassStmt) *parser.Value {
+	enclosingClass := r.currentClass
+	r.currentClass = classClass
+
+	r.declare(stmt.Name)
+	r.define(stmt.Name)
+
+	if stmt.Superclass != nil {
+		if stmt.Superclass.Name.Lexeme == stmt.Name.Lexeme {
+			r.error(stmt.Superclass.Name, "a class can't inherit from itself")
+		}
+		r.currentClass = classSubclass
+		r.resolveExpr(stmt.Superclass)
+
+		r.beginScope()
+		r.scopes[len(r.scopes)-1]["super"] = true
+	}
+
+	r.beginScope()
+	r.scopes[len(r.scopes)-1]["this"] = true
+	for _, method := range stmt.Methods {
+		kind := functionMethod
+		if method.Name.Lexeme == "init" {
+			kind = functionInitializer
+		}
+		r.resolveFunction(method, kind)
+	}
+	r.endScope()
+
+	if stmt.Superclass != nil {
+		r.endScope()
+	}
+
+	r.currentClass = enclosingClass
+	return nil
+}
+
+func (r *Resolver) VisitForInStmt(stmt *parser.ForInStmt) *parser.Value {
+	r.resolveExpr(stmt.Iterable)
+
+	r.beginScope()
+	r.declare(stmt.Name)
+	r.define(stmt.Name)
+	r.resolveStmt(stmt.Body)
+	r.endScope()
+	return nil
+}
+
+func (r *Resolver) VisitThrowStmt(stmt *parser.ThrowStmt) *parser.Value {
+	r.resolveExpr(stmt.Value)
+	return nil
+}
+
+func (r *Resolver) VisitTryStmt(stmt *parser.TryStmt) *parser.Value {
+	r.beginScope()
+	r.resolveStmts(stmt.Body)
+	r.endScope()
+
+	if stmt.Catch != nil {
+		r.beginScope()
+		r.declare(stmt.CatchName)
+		r.define(stmt.CatchName)
+		r.resolveStmts(stmt.Catch)
+		r.endScope()
+	}
+	if stmt.Finally != nil {
+		r.beginScope()
+		r.resolveStmts(stmt.Finally)
+		r.endScope()
+	}
+	return nil
+}
+
+func (r *Resolver) VisitMatchStmt(stmt *parser.MatchStmt) *parser.Value {
+	r.checkMatch(stmt)
+	r.resolveExpr(stmt.Subject)
+	for _, arm := range stmt.Arms {
+		r.beginScope()
+		r.bindPattern(arm.Pattern)
+		if arm.Guard != nil {
+			r.resolveExpr(arm.Guard)
+		}
+		r.resolveStmt(arm.Body)
+		r.endScope()
+	}
+	return nil
+}
+
+// bindPattern declares the names a match pattern binds in the current
+// scope.
+func (r *Resolver) bindPattern(pattern parser.Pattern) {
+	switch pattern := pattern.(type) {
+	case *parser.BindingPattern:
+		r.declare(pattern.Name)
+		r.define(pattern.Name)
+	case *parser.MemberPattern:
+		r.resolveExpr(pattern.Value)
+	case *parser.ListPattern:
+		for _, element := range pattern.Elements {
+			r.bindPattern(element)
+		}
+	case *parser.AlternativePattern:
+		// Each alternative may match on its own, so none can be relied on
+		// to bind a name.
+		for _, alternative := range pattern.Alternatives {
+			if name := firstBinding(alternative); name != nil {
+				r.error(name, "alternative patterns can't bind variables")
+			} else {
+				r.bindPattern(alternative)
+			}
+		}
+	}
+}
+
+func firstBinding(pattern parser.Pattern) *ls.Token {
+	switch pattern := pattern.(type) {
+	case *parser.BindingPattern:
+		return pattern.Name
+	case *parser.ListPattern:
+		for _, element := range pattern.Elements {
+			if name := firstBinding(element); name != nil {
+				return name
+			}
+		}
+	case *parser.AlternativePattern:
+		for _, alternative := range pattern.Alternatives {
+			if name := firstBinding(alternative); name != nil {
+				return name
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Resolver) VisitEnumStmt(stmt *parser.EnumStmt) *parser.Value {
+	r.declare(stmt.Name)
+	r.define(stmt.Name)
+	r.markConstant(stmt.Name, true)
+
+	members := make([]string, 0, len(stmt.Members))
+	seen := make(map[string]bool, len(stmt.Members))
+	for _, member := range stmt.Members {
+		if seen[member.Lexeme] {
+			r.error(member, "duplicate enum member '"+member.Lexeme+"'")
+		}
+		seen[member.Lexeme] = true
+		members = append(members, member.Lexeme)
+	}
+	if len(r.scopes) == 0 {
+		r.globalEnums[stmt.Name.Lexeme] = members
+	} else {
+		r.enums[len(r.enums)-1][stmt.Name.Lexeme] = members
+	}
+	return nil
+}
+
+func (r *Resolver) VisitImportStmt(stmt *parser.ImportStmt) *parser.Value {
+	r.declare(stmt.Alias)
+	r.define(stmt.Alias)
+	r.markConstant(stmt.Alias, false)
+	return nil
+}
+
+func (r *Resolver) VisitExportStmt(stmt *parser.ExportStmt) *parser.Value {
+	if len(r.scopes) > 0 {
+		r.error(stmt.Keyword, "can only export top-level declarations")
+	}
+	r.resolveStmt(stmt.Declaration)
+	return nil
+}
+
+// Implement ExprVisitor
+func (r *Resolver) VisitBinary(expr *parser.Binary) *parser.Value {
+	r.resolveExpr(expr.Left)
+	r.resolveExpr(expr.Right)
+	return nil
+}
+
+func (r *Resolver) VisitUnary(expr *parser.Unary) *parser.Value {
+	r.resolveExpr(expr.Right)
+	return nil
+}
+
+func (r *Resolver) VisitLiteral(expr *parser.Literal) *parser.Value {
+	return nil
+}
+
+func (r *Resolver) VisitVariable(expr *parser.Variable) *parser.Value {
+	if len(r.scopes) > 0 {
+		if ready, declared := r.scopes[len(r.scopes)-1][expr.Name.Lexeme]; declared && !ready {
+			r.error(expr.Name, "can't read local variable in its own initializer")
+		}
+	}
+	r.resolveLocal(expr, expr.Name)
+	return nil
+}
+
+func (r *Resolver) VisitAssign(expr *parser.Assign) *parser.Value {
+	r.resolveExpr(expr.Expr)
+	r.resolveLocal(expr, expr.Name)
+	r.checkAssignable(expr.Name)
+	return nil
+}
+
+func (r *Resolver) VisitDestructureAssign(expr *parser.DestructureAssign) *parser.Value {
+	r.resolveExpr(expr.Value)
+	for _, target := range expr.Targets {
+		r.resolveLocal(target, target.Name)
+		r.checkAssignable(target.Name)
+	}
+	return nil
+}
+
+func (r *Resolver) VisitLogical(expr *parser.Logical) *parser.Value {
+	r.resolveExpr(expr.Left)
+	r.resolveExpr(expr.Right)
+	return nil
+}
+
+func (r *Resolver) VisitCall(expr *parser.Call) *parser.Value {
+	r.resolveExpr(expr.Callee)
+	for _, arg := range expr.Arguments {
+		r.resolveExpr(arg)
+	}
+	return nil
+}
+
+func (r *Resolver) VisitGet(expr *parser.Get) *parser.Value {
+	r.resolveExpr(expr.Object)
+	r.checkEnumMember(expr)
+	return nil
+}
+
+func (r *Resolver) VisitSet(expr *parser.Set) *parser.Value {
+	r.resolveExpr(expr.Value)
+	r.resolveExpr(expr.Object)
+	return nil
+}
+
+func (r *Resolver) VisitThis(expr *parser.This) *parser.Value {
+	if r.currentClass == classNone {
+		r.error(expr.Keyword, "can't use 'this' outside of a class")
+		return nil
+	}
+	r.resolveLocal(expr, expr.Keyword)
+	return nil
+}
+
+func (r *Resolver) VisitSuper(expr *parser.Super) *parser.Value {
+	switch r.currentClass {
+	case classNone:
+		r.error(expr.Keyword, "can't use 'super' outside of a class")
+	case classClass:
+		r.error(expr.Keyword, "can't use 'super' in a class with no superclass")
+	}
+	r.resolveLocal(expr, expr.Keyword)
+	return nil
+}
+
+func (r *Resolver) VisitConditional(expr *parser.Conditional) *parser.Value {
+	r.resolveExpr(expr.Condition)
+	r.resolveExpr(expr.ThenBranch)
+	r.resolveExpr(expr.ElseBranch)
+	return nil
+}
+
+func (r *Resolver) VisitUpdate(expr *parser.Update) *parser.Value {
+	r.resolveExpr(expr.Target)
+	if variable, ok := expr.Target.(*parser.Variable); ok {
+		r.checkAssignable(variable.Name)
+	}
+	return nil
+}
+
+func (r *Resolver) VisitListLiteral(expr *parser.ListLiteral) *parser.Value {
+	for _, element := range expr.Elements {
+		r.resolveExpr(element)
+	}
+	return nil
+}
+
+func (r *Resolver) VisitIndex(expr *parser.Index) *parser.Value {
+	r.resolveExpr(expr.Object)
+	r.resolveExpr(expr.Index)
+	return nil
+}
+
+func (r *Resolver) VisitIndexSet(expr *parser.IndexSet) *parser.Value {
+	r.resolveExpr(expr.Value)
+	r.resolveExpr(expr.Object)
+	r.resolveExpr(expr.Index)
+	return nil
+}
+
+func (r *Resolver) VisitSlice(expr *parser.Slice) *parser.Value {
+	r.resolveExpr(expr.Object)
+	if expr.Start != nil {
+		r.resolveExpr(expr.Start)
+	}
+	if expr.End != nil {
+		r.resolveExpr(expr.End)
+	}
+	return nil
+}
+
+func (r *Resolver) VisitMapLiteral(expr *parser.MapLiteral) *parser.Value {
+	for idx, key := range expr.Keys {
+		r.resolveExpr(key)
+		r.resolveExpr(expr.Values[idx])
+	}
+	return nil
+}
+
+func (r *Resolver) VisitLambda(expr *parser.Lambda) *parser.Value {
+	r.resolveFunction(expr.Function, functionFunction)
+	return nil
+}
+
+func (r *Resolver) VisitRange(expr *parser.RangeExpr) *parser.Value {
+	r.resolveExpr(expr.Start)
+	r.resolveExpr(expr.End)
+	return nil
+}
+
+// Helper methods
+func (r *Resolver) resolveStmts(stmts []parser.Stmt) {
+	for _, stmt := range stmts {
+		r.resolveStmt(stmt)
+	}
+}
+
+func (r *Resolver) resolveStmt(stmt parser.Stmt) {
+	stmt.Accept(r)
+}
+
+func (r *Resolver) resolveExpr(expr parser.Expr) {
+	expr.Accept(r)
+}
+
+func (r *Resolver) resolveFunction(function *parser.FunctionStmt, kind functionType) {
+	enclosingFunction := r.currentFunction
+	r.currentFunction = kind
+
+	r.beginScope()
+	for _, param := range function.Params {
+		r.declare(param)
+		r.define(param)
+	}
+	r.resolveStmts(function.Body)
+	r.endScope()
+
+	r.currentFunction = enclosingFunction
+}
+
+// resolveLocal records the depth of the innermost scope declaring name.
+func (r *Resolver) resolveLocal(expr parser.Expr, name *ls.Token) {
+	for idx := len(r.scopes) - 1; idx >= 0; idx-- {
+		if _, ok := r.scopes[idx][name.Lexeme]; ok {
+			r.interpreter.Resolve(expr, len(r.scopes)-1-idx)
+			return
+		}
+	}
+}
+
+// markConstant records whether the variable just declared as name in the
+// current scope is a constant.
+func (r *Resolver) markConstant(name *ls.Token, isConst bool) {
+	if len(r.scopes) == 0 {
+		r.globalConstants[name.Lexeme] = isConst
+	} else if isConst {
+		r.constants[len(r.constants)-1][name.Lexeme] = true
+	}
+}
+
+// lookUpEnum returns the members of the enum name refers to at this
+// point, or false if it refers to something else.
+func (r *Resolver) lookUpEnum(name string) ([]string, bool) {
+	for idx := len(r.scopes) - 1; idx >= 0; idx-- {
+		if _, ok := r.scopes[idx][name]; ok {
+			members, ok := r.enums[idx][name]
+			return members, ok
+		}
+	}
+	members, ok := r.globalEnums[name]
+	return members, ok
+}
+
+// checkAssignable reports an assignment to name if it resolves to a
+// constant. Globals declared by earlier input, as in the REPL, are left
+// to the runtime check in Env.
+func (r *Resolver) checkAssignable(name *ls.Token) {
+	for idx := len(r.scopes) - 1; idx >= 0; idx-- {
+		if _, ok := r.scopes[idx][name.Lexeme]; ok {
+			if r.constants[idx][name.Lexeme] {
+				r.error(name, "can't assign to constant '"+name.Lexeme+"'")
+			}
+			return
+		}
+	}
+	if r.globalConstants[name.Lexeme] {
+		r.error(name, "can't assign to constant '"+name.Lexeme+"'")
+	}
+}
+
+func (r *Resolver) beginScope() {
+	r.scopes = append(r.scopes, make(map[string]bool))
+	r.constants = append(r.constants, make(map[string]bool))
+	r.enums = append(r.enums, make(map[string][]string))
+}
+
+func (r *Resolver) endScope() {
+	r.scopes = r.scopes[:len(r.scopes)-1]
+	r.constants = r.constants[:len(r.constants)-1]
+	r.enums = r.enums[:len(r.enums)-1]
+}
+
+func (r *Resolver) declare(name *ls.Token) {
+	if len(r.scopes) == 0 {
+		if r.globalConstants[name.Lexeme] {
+			r.error(name, "can't redeclare constant '"+name.Lexeme+"'")
+		}
+		return
+	}
+	scope := r.scopes[len(r.scopes)-1]
+	if _, ok := scope[name.Lexeme]; ok {
+		r.error(name, "already a variable with this name in this scope")
+	}
+	scope[name.Lexeme] = false
+}
+
+func (r *Resolver) define(name *ls.Token) {
+	if len(r.scopes) == 0 {
+		return
+	}
+	r.scopes[len(r.scopes)-1][name.Lexeme] = true
+}
+
+func (r *Resolver) warn(token *ls.Token, message string) {
+	r.warnings = append(r.warnings, ResolveWarning{
+		Token:   *token,
+		Message: message,
+	})
+}
+
+func (r *Resolver) error(token *ls.Token, message string) {
+	r.errors = append(r.errors, ResolveError{
+		Token:   *token,
+		Message: message,
+	})
+}
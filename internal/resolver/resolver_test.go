@@ -0,0 +1,151 @@
+package resolver
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Piyush01Bhatt/interpreter_go/internal/interpreter"
+	"github.com/Piyush01Bhatt/interpreter_go/internal/parser"
+	ls "github.com/Piyush01Bhatt/interpreter_go/internal/scanner"
+)
+
+func resolve(t *testing.T, source string) *Resolver {
+	t.Helper()
+	tokens, scanErrors := ls.NewLexScanner(source).ScanTokens()
+	if len(scanErrors) > 0 {
+		t.Fatalf("%q: unexpected scan errors %v", source, scanErrors)
+	}
+	statements, parseErrors := parser.NewParser(tokens).Parse()
+	if len(parseErrors) > 0 {
+		t.Fatalf("%q: unexpected parse errors %v", source, parseErrors)
+	}
+	r := NewResolver(interpreter.NewInterpreter(interpreter.ModeFile))
+	r.Resolve(statements)
+	return r
+}
+
+// resolveTest is a source and the errors resolving it must report, in
+// order.
+type resolveTest struct {
+	source   string
+	wantErrs []string
+}
+
+func runResolveTests(t *testing.T, tests []resolveTest) {
+	t.Helper()
+	for _, test := range tests {
+		errs := resolve(t, test.source).errors
+		if len(errs) != len(test.wantErrs) {
+			t.Errorf("%q: got errors %v, want %v", test.source, errs, test.wantErrs)
+			continue
+		}
+		for idx, err := range errs {
+			if err.Error() != test.wantErrs[idx] {
+				t.Errorf("%q: error %d is %q, want %q", test.source, idx, err.Error(), test.wantErrs[idx])
+			}
+		}
+	}
+}
+
+func TestResolveScopeErrors(t *testing.T) {
+	runResolveTests(t, []resolveTest{
+		{"var a = 1; { var b = a; }", nil},
+		{"{ var a = a; }", []string{"[line 1:11] Error at 'a': can't read local variable in its own initializer"}},
+		{"{ var a = 1; var a = 2; }", []string{"[line 1:18] Error at 'a': already a variable with this name in this scope"}},
+		{"return 1;", []string{"[line 1:1] Error at 'return': can't return from top-level code"}},
+		{"fun f() { return 1; }", nil},
+	})
+}
+
+func TestResolveClassErrors(t *testing.T) {
+	runResolveTests(t, []resolveTest{
+		{"print this;", []string{"[line 1:7] Error at 'this': can't use 'this' outside of a class"}},
+		{"class C { init() { return 1; } }", []string{"[line 1:20] Error at 'return': can't return a value from an initializer"}},
+		{"class C { init() { return; } }", nil},
+	})
+}
+
+func TestResolveSuperErrors(t *testing.T) {
+	runResolveTests(t, []resolveTest{
+		{"class A < A {}", []string{"[line 1:11] Error at 'A': a class can't inherit from itself"}},
+		{"super.m();", []string{"[line 1:1] Error at 'super': can't use 'super' outside of a class"}},
+		{"class A { m() { super.m(); } }", []string{"[line 1:17] Error at 'super': can't use 'super' in a class with no superclass"}},
+	})
+}
+
+func TestResolveMatchErrors(t *testing.T) {
+	runResolveTests(t, []resolveTest{
+		{"var x = 1; match (x) { case [a, b] => print a + b; }", nil},
+		{"var x = 1; match (x) { case a | 1 => print 1; }", []string{"[line 1:29] Error at 'a': alternative patterns can't bind variables"}},
+	})
+}
+
+func TestResolveConstErrors(t *testing.T) {
+	runResolveTests(t, []resolveTest{
+		{"const A = 1; A = 2;", []string{"[line 1:14] Error at 'A': can't assign to constant 'A'"}},
+		{"const A = 1; A += 1;", []string{"[line 1:14] Error at 'A': can't assign to constant 'A'"}},
+		{"{ const A = 1; A++; }", []string{"[line 1:16] Error at 'A': can't assign to constant 'A'"}},
+		{"const A = 1; fun f() { A = 2; }", []string{"[line 1:24] Error at 'A': can't assign to constant 'A'"}},
+		{"const A = 1; var A = 2;", []string{"[line 1:18] Error at 'A': can't redeclare constant 'A'"}},
+		{"const A = 1; class A {}", []string{"[line 1:20] Error at 'A': can't redeclare constant 'A'"}},
+		{"{ const A = 1; { var A = 2; A = 3; } }", nil},
+	})
+}
+
+func TestResolveDestructuringErrors(t *testing.T) {
+	runResolveTests(t, []resolveTest{
+		{"const [a, b] = [1, 2]; [a, b] = [b, a];", []string{
+			"[line 1:25] Error at 'a': can't assign to constant 'a'",
+			"[line 1:28] Error at 'b': can't assign to constant 'b'",
+		}},
+		{"{ var [a, a] = [1, 2]; }", []string{"[line 1:11] Error at 'a': already a variable with this name in this scope"}},
+	})
+}
+
+func TestResolveExportErrors(t *testing.T) {
+	runResolveTests(t, []resolveTest{
+		{"export fun f() {}", nil},
+		{"{ export var x = 1; }", []string{"[line 1:3] Error at 'export': can only export top-level declarations"}},
+	})
+}
+
+func TestResolveEnumErrors(t *testing.T) {
+	runResolveTests(t, []resolveTest{
+		{"enum Color { Red, Red }", []string{"[line 1:19] Error at 'Red': duplicate enum member 'Red'"}},
+		{"enum Color { Red } var c = Color.Red; match (c) { case Color.Gren => print 1; case _ => print 2; }",
+			[]string{"[line 1:62] Error at 'Gren': enum 'Color' has no member 'Gren'"}},
+		{"enum Color { Red } var c = Color.Red; if (c == Color.Gren) print 1;",
+			[]string{"[line 1:54] Error at 'Gren': enum 'Color' has no member 'Gren'"}},
+		{"enum Color { Red } fun f() { var Color = nil; return Color.Gren; }", nil},
+	})
+}
+
+func TestResolveEnumWarnings(t *testing.T) {
+	tests := []struct {
+		source string
+		want   []string
+	}{
+		{"enum C { R, G, B } var c = C.R; match (c) { case C.R => print 1; case C.G => print 2; }",
+			[]string{"[line 1:33] Warning at 'match': match over enum 'C' doesn't handle B"}},
+		{"enum C { R, G } var c = C.R; match (c) { case C.R | C.G => print 1; }", nil},
+		{"enum C { R, G } var c = C.R; match (c) { case C.R => print 1; case _ => print 2; }", nil},
+		{"enum C { R, G } var c = C.R; match (c) { case C.R => print 1; case C.G if false => print 2; }",
+			[]string{"[line 1:30] Warning at 'match': match over enum 'C' doesn't handle G"}},
+		{"enum C { R, G, B } var c = C.R; if (c == C.R) print 1; else if (c == C.G) print 2;",
+			[]string{"[line 1:37] Warning at 'c': if chain over enum 'C' doesn't handle B"}},
+		{"enum C { R, G, B } var c = C.R; if (c == C.R) print 1; else if (c == C.G) print 2; else print 3;", nil},
+	}
+	for _, test := range tests {
+		r := resolve(t, test.source)
+		if len(r.errors) > 0 {
+			t.Errorf("%q: unexpected errors %v", test.source, r.errors)
+		}
+		got := make([]string, len(r.Warnings()))
+		for idx, warning := range r.Warnings() {
+			got[idx] = warning.String()
+		}
+		if !slices.Equal(got, test.want) && len(got)+len(test.want) > 0 {
+			t.Errorf("%q: got warnings %v, want %v", test.source, got, test.want)
+		}
+	}
+}
@@ -1,8 +1,9 @@
 package scanner
 
 import (
-	"log"
+	"fmt"
 	"strconv"
+	"strings"
 	"unicode"
 
 	u "github.com/Piyush01Bhatt/interpreter_go/internal/utils"
@@ -16,6 +17,8 @@ const (
 	RIGHT_PAREN
 	LEFT_BRACE
 	RIGHT_BRACE
+	LEFT_BRACKET
+	RIGHT_BRACKET
 	COMMA
 	DOT
 	MINUS
@@ -23,6 +26,13 @@ const (
 	SEMICOLON
 	SLASH
 	STAR
+	PERCENT
+	AMPERSAND
+	PIPE
+	CARET
+	TILDE
+	QUESTION
+	COLON
 
 	// One or two character tokens.
 	BANG
@@ -31,8 +41,21 @@ const (
 	EQUAL_EQUAL
 	GREATER
 	GREATER_EQUAL
+	GREATER_GREATER
 	LESS
 	LESS_EQUAL
+	LESS_LESS
+	STAR_STAR
+	TILDE_SLASH
+	PLUS_EQUAL
+	MINUS_EQUAL
+	STAR_EQUAL
+	SLASH_EQUAL
+	PERCENT_EQUAL
+	PLUS_PLUS
+	MINUS_MINUS
+	ARROW
+	DOT_DOT
 
 	// Literals.
 	IDENTIFIER
@@ -41,19 +64,31 @@ const (
 
 	// Keywords.
 	AND
+	AS
+	CASE
+	CATCH
 	CLASS
+	CONST
 	ELSE
+	ENUM
+	EXPORT
 	FALSE
+	FINALLY
 	FUN
 	FOR
 	IF
+	IMPORT
+	IN
+	MATCH
 	NIL
 	OR
 	PRINT
 	RETURN
 	SUPER
 	THIS
+	THROW
 	TRUE
+	TRY
 	VAR
 	WHILE
 
@@ -67,57 +102,128 @@ type Token struct {
 	Lexeme  string
 	Literal any
 	Line    int
+	Column  int // 1-based byte column of the first character
+	Offset  int // byte offset of the first character in the source
+	Length  int // length of the lexeme in bytes
+}
+
+// Span is a range of source text, identified by the position of its
+// first character and the byte offset just past its last one.
+type Span struct {
+	Line   int
+	Column int
+	Start  int
+	End    int
+}
+
+// Span returns the source range covered by the token.
+func (t Token) Span() Span {
+	return Span{
+		Line:   t.Line,
+		Column: t.Column,
+		Start:  t.Offset,
+		End:    t.Offset + t.Length,
+	}
+}
+
+// Merge returns the smallest span covering both s and other.
+func (s Span) Merge(other Span) Span {
+	merged := s
+	if other.Start < merged.Start {
+		merged.Line = other.Line
+		merged.Column = other.Column
+		merged.Start = other.Start
+	}
+	if other.End > merged.End {
+		merged.End = other.End
+	}
+	return merged
+}
+
+// ScanError describes malformed input found while scanning.
+type ScanError struct {
+	Message string
+	Line    int
+	Column  int
+}
+
+func (e ScanError) Error() string {
+	return fmt.Sprintf("[line %d:%d] %s", e.Line, e.Column, e.Message)
 }
 
 // LexScanner represents a scanner to scan tokens.
 type LexScanner struct {
-	source  string
-	tokens  []Token
-	start   int
-	current int
-	line    int
+	source      string
+	tokens      []Token
+	errors      []ScanError
+	start       int
+	current     int
+	line        int
+	lineStart   int // byte offset at which the current line begins
+	startLine   int // line of the lexeme being scanned
+	startColumn int // column of the lexeme being scanned
 }
 
 func NewLexScanner(input string) *LexScanner {
 	return &LexScanner{
-		source:  input,
-		tokens:  make([]Token, 0),
-		start:   0,
-		current: 0,
-		line:    1,
+		source:    input,
+		tokens:    make([]Token, 0),
+		errors:    make([]ScanError, 0),
+		start:     0,
+		current:   0,
+		line:      1,
+		lineStart: 0,
 	}
 }
 
 // Token type names (for debugging/logging).
 var tokenTypeNames = [...]string{
-	"LEFT_PAREN", "RIGHT_PAREN", "LEFT_BRACE", "RIGHT_BRACE",
+	"LEFT_PAREN", "RIGHT_PAREN", "LEFT_BRACE", "RIGHT_BRACE", "LEFT_BRACKET", "RIGHT_BRACKET",
 	"COMMA", "DOT", "MINUS", "PLUS", "SEMICOLON", "SLASH", "STAR",
+	"PERCENT", "AMPERSAND", "PIPE", "CARET", "TILDE", "QUESTION", "COLON",
 	"BANG", "BANG_EQUAL", "EQUAL", "EQUAL_EQUAL",
-	"GREATER", "GREATER_EQUAL", "LESS", "LESS_EQUAL",
+	"GREATER", "GREATER_EQUAL", "GREATER_GREATER", "LESS", "LESS_EQUAL", "LESS_LESS",
+	"STAR_STAR", "TILDE_SLASH",
+	"PLUS_EQUAL", "MINUS_EQUAL", "STAR_EQUAL", "SLASH_EQUAL", "PERCENT_EQUAL",
+	"PLUS_PLUS", "MINUS_MINUS", "ARROW", "DOT_DOT",
 	"IDENTIFIER", "STRING", "NUMBER",
-	"AND", "CLASS", "ELSE", "FALSE", "FUN", "FOR", "IF", "NIL", "OR",
-	"PRINT", "RETURN", "SUPER", "THIS", "TRUE", "VAR", "WHILE",
+	"AND", "AS", "CASE", "CATCH", "CLASS", "CONST", "ELSE", "ENUM", "EXPORT", "FALSE", "FINALLY", "FUN", "FOR",
+	"IF", "IMPORT", "IN", "MATCH",
+	"NIL", "OR",
+	"PRINT", "RETURN", "SUPER", "THIS", "THROW", "TRUE", "TRY", "VAR", "WHILE",
 	"EOF",
 }
 
 // Keywords map for fast lookup.
 var keywordsMap = map[string]TokenType{
-	"and":    AND,
-	"class":  CLASS,
-	"else":   ELSE,
-	"false":  FALSE,
-	"fun":    FUN,
-	"for":    FOR,
-	"if":     IF,
-	"nil":    NIL,
-	"or":     OR,
-	"print":  PRINT,
-	"return": RETURN,
-	"super":  SUPER,
-	"this":   THIS,
-	"true":   TRUE,
-	"var":    VAR,
-	"while":  WHILE,
+	"and":     AND,
+	"as":      AS,
+	"case":    CASE,
+	"catch":   CATCH,
+	"class":   CLASS,
+	"const":   CONST,
+	"else":    ELSE,
+	"enum":    ENUM,
+	"export":  EXPORT,
+	"false":   FALSE,
+	"finally": FINALLY,
+	"fun":     FUN,
+	"for":     FOR,
+	"if":      IF,
+	"import":  IMPORT,
+	"in":      IN,
+	"match":   MATCH,
+	"nil":     NIL,
+	"or":      OR,
+	"print":   PRINT,
+	"return":  RETURN,
+	"super":   SUPER,
+	"this":    THIS,
+	"throw":   THROW,
+	"true":    TRUE,
+	"try":     TRY,
+	"var":     VAR,
+	"while":   WHILE,
 }
 
 // String method for debugging.
@@ -136,15 +242,25 @@ func (t *Token) String() string {
 	return t.Type.String() + " " + t.Lexeme + " " + literalStr
 }
 
-func (ls *LexScanner) ScanTokens() []Token {
+// ScanTokens scans the whole source. Malformed input is skipped and
+// reported in the returned errors so scanning can carry on past it.
+func (ls *LexScanner) ScanTokens() ([]Token, []ScanError) {
 	for !ls.isAtEnd() {
 		// We are at the beginning of the next lexeme.
-		ls.start = ls.current
+		ls.markStart()
 		ls.scan()
 	}
 
+	ls.markStart()
 	ls.addToken(EOF, nil)
-	return ls.tokens
+	return ls.tokens, ls.errors
+}
+
+// markStart records the position of the lexeme about to be scanned.
+func (ls *LexScanner) markStart() {
+	ls.start = ls.current
+	ls.startLine = ls.line
+	ls.startColumn = ls.current - ls.lineStart + 1
 }
 
 func (ls *LexScanner) isAtEnd() bool {
@@ -162,38 +278,81 @@ func (ls *LexScanner) scan() {
 		ls.addToken(LEFT_BRACE, nil)
 	case '}':
 		ls.addToken(RIGHT_BRACE, nil)
+	case '[':
+		ls.addToken(LEFT_BRACKET, nil)
+	case ']':
+		ls.addToken(RIGHT_BRACKET, nil)
 	case ',':
 		ls.addToken(COMMA, nil)
 	case '.':
-		ls.addToken(DOT, nil)
+		ls.addToken(u.Ternary(ls.match('.'), DOT_DOT, DOT), nil)
 	case '-':
-		ls.addToken(MINUS, nil)
+		if ls.match('-') {
+			ls.addToken(MINUS_MINUS, nil)
+		} else {
+			ls.addToken(u.Ternary(ls.match('='), MINUS_EQUAL, MINUS), nil)
+		}
 	case '+':
-		ls.addToken(PLUS, nil)
+		if ls.match('+') {
+			ls.addToken(PLUS_PLUS, nil)
+		} else {
+			ls.addToken(u.Ternary(ls.match('='), PLUS_EQUAL, PLUS), nil)
+		}
 	case ';':
 		ls.addToken(SEMICOLON, nil)
 	case '*':
-		ls.addToken(STAR, nil)
+		if ls.match('*') {
+			ls.addToken(STAR_STAR, nil)
+		} else {
+			ls.addToken(u.Ternary(ls.match('='), STAR_EQUAL, STAR), nil)
+		}
+	case '%':
+		ls.addToken(u.Ternary(ls.match('='), PERCENT_EQUAL, PERCENT), nil)
+	case '&':
+		ls.addToken(AMPERSAND, nil)
+	case '|':
+		ls.addToken(PIPE, nil)
+	case '^':
+		ls.addToken(CARET, nil)
+	case '~':
+		ls.addToken(u.Ternary(ls.match('/'), TILDE_SLASH, TILDE), nil)
+	case '?':
+		ls.addToken(QUESTION, nil)
+	case ':':
+		ls.addToken(COLON, nil)
 	case '!':
 		ls.addToken(u.Ternary(ls.match('='), BANG_EQUAL, BANG), nil)
 	case '=':
-		ls.addToken(u.Ternary(ls.match('='), EQUAL_EQUAL, EQUAL), nil)
+		if ls.match('>') {
+			ls.addToken(ARROW, nil)
+		} else {
+			ls.addToken(u.Ternary(ls.match('='), EQUAL_EQUAL, EQUAL), nil)
+		}
 	case '>':
-		ls.addToken(u.Ternary(ls.match('='), GREATER_EQUAL, GREATER), nil)
+		if ls.match('>') {
+			ls.addToken(GREATER_GREATER, nil)
+		} else {
+			ls.addToken(u.Ternary(ls.match('='), GREATER_EQUAL, GREATER), nil)
+		}
 	case '<':
-		ls.addToken(u.Ternary(ls.match('='), LESS_EQUAL, LESS), nil)
+		if ls.match('<') {
+			ls.addToken(LESS_LESS, nil)
+		} else {
+			ls.addToken(u.Ternary(ls.match('='), LESS_EQUAL, LESS), nil)
+		}
 	case '/':
-		if ls.match('=') {
+		if ls.match('/') {
+			// A comment goes until the end of the line.
 			for ls.peek() != '\n' && !ls.isAtEnd() {
 				ls.advance()
 			}
 		} else {
-			ls.addToken(SLASH, nil)
+			ls.addToken(u.Ternary(ls.match('='), SLASH_EQUAL, SLASH), nil)
 		}
 	case ' ', '\r', '\t':
 		// Ignore whitespace
 	case '\n':
-		ls.line++
+		// Line bookkeeping happens in advance
 	case '"':
 		ls.readString()
 	default:
@@ -201,17 +360,21 @@ func (ls *LexScanner) scan() {
 			ls.readNumber()
 			return
 		}
-		if unicode.IsLetter(rune(ch)) {
+		if unicode.IsLetter(rune(ch)) || ch == '_' {
 			ls.readIdentifier()
 			return
 		}
-		log.Fatalf("unexpected character at line: %d", ls.line)
+		ls.addError(fmt.Sprintf("unexpected character '%c'", ch))
 	}
 }
 
 func (ls *LexScanner) advance() byte {
 	char := ls.source[ls.current]
 	ls.current++
+	if char == '\n' {
+		ls.line++
+		ls.lineStart = ls.current
+	}
 	return char
 }
 
@@ -232,14 +395,26 @@ func (ls *LexScanner) addToken(tokenType TokenType, literal any) {
 		Type:    tokenType,
 		Lexeme:  lexeme,
 		Literal: literal,
-		Line:    ls.line,
+		Line:    ls.startLine,
+		Column:  ls.startColumn,
+		Offset:  ls.start,
+		Length:  ls.current - ls.start,
 	}
 	ls.tokens = append(ls.tokens, token)
 }
 
+// addError records an error at the start of the current lexeme.
+func (ls *LexScanner) addError(message string) {
+	ls.errors = append(ls.errors, ScanError{
+		Message: message,
+		Line:    ls.startLine,
+		Column:  ls.startColumn,
+	})
+}
+
 func (ls *LexScanner) peek() byte {
 	if ls.isAtEnd() {
-		return '0'
+		return 0
 	}
 	return ls.source[ls.current]
 }
@@ -247,20 +422,17 @@ func (ls *LexScanner) peek() byte {
 func (ls *LexScanner) peekNext() byte {
 	next := ls.current + 1
 	if next >= len(ls.source) {
-		return '0'
+		return 0
 	}
 	return ls.source[next]
 }
 
 func (ls *LexScanner) readString() {
 	for ls.peek() != '"' && !ls.isAtEnd() {
-		if ls.peek() == '\n' {
-			ls.line++
-		}
 		ls.advance()
 	}
 	if ls.isAtEnd() {
-		log.Fatalf("Unterminated string at line: %d", ls.line)
+		ls.addError("unterminated string")
 		return
 	}
 	ls.advance()
@@ -279,7 +451,16 @@ func (ls *LexScanner) readNumber() {
 		}
 	}
 	lexeme := ls.source[ls.start:ls.current]
-	value, _ := strconv.ParseFloat(lexeme, 64)
+	if strings.ContainsRune(lexeme, '.') {
+		value, _ := strconv.ParseFloat(lexeme, 64)
+		ls.addToken(NUMBER, value)
+		return
+	}
+	value, err := strconv.Atoi(lexeme)
+	if err != nil {
+		ls.addError("integer literal out of range")
+		return
+	}
 	ls.addToken(NUMBER, value)
 }
 
@@ -0,0 +1,125 @@
+package scanner
+
+import (
+	"slices"
+	"testing"
+)
+
+func tokenTypes(tokens []Token) []TokenType {
+	types := make([]TokenType, len(tokens))
+	for idx, token := range tokens {
+		types[idx] = token.Type
+	}
+	return types
+}
+
+func TestScanTokens(t *testing.T) {
+	tests := []struct {
+		source string
+		want   []TokenType
+	}{
+		{"", []TokenType{EOF}},
+		{"var x = 1;", []TokenType{VAR, IDENTIFIER, EQUAL, NUMBER, SEMICOLON, EOF}},
+		{"a <= b != c", []TokenType{IDENTIFIER, LESS_EQUAL, IDENTIFIER, BANG_EQUAL, IDENTIFIER, EOF}},
+		{"a.b", []TokenType{IDENTIFIER, DOT, IDENTIFIER, EOF}},
+		{`"text"`, []TokenType{STRING, EOF}},
+		{"x ~/ 2 ** 3", []TokenType{IDENTIFIER, TILDE_SLASH, NUMBER, STAR_STAR, NUMBER, EOF}},
+		{"~a & b << 1 >> c", []TokenType{TILDE, IDENTIFIER, AMPERSAND, IDENTIFIER, LESS_LESS, NUMBER, GREATER_GREATER, IDENTIFIER, EOF}},
+		{"i += 1; i++", []TokenType{IDENTIFIER, PLUS_EQUAL, NUMBER, SEMICOLON, IDENTIFIER, PLUS_PLUS, EOF}},
+		{"a ? b : c", []TokenType{IDENTIFIER, QUESTION, IDENTIFIER, COLON, IDENTIFIER, EOF}},
+		{"// comment only", []TokenType{EOF}},
+		{"print 1 // trailing", []TokenType{PRINT, NUMBER, EOF}},
+		{"x => x", []TokenType{IDENTIFIER, ARROW, IDENTIFIER, EOF}},
+		{"0..10", []TokenType{NUMBER, DOT_DOT, NUMBER, EOF}},
+		{"x in xs", []TokenType{IDENTIFIER, IN, IDENTIFIER, EOF}},
+	}
+	for _, test := range tests {
+		tokens, errs := NewLexScanner(test.source).ScanTokens()
+		if len(errs) > 0 {
+			t.Errorf("%q: unexpected errors %v", test.source, errs)
+			continue
+		}
+		if got := tokenTypes(tokens); !slices.Equal(got, test.want) {
+			t.Errorf("%q: got %v, want %v", test.source, got, test.want)
+		}
+	}
+}
+
+func TestScanNumberAtEndOfInput(t *testing.T) {
+	tests := []struct {
+		source  string
+		literal any
+	}{
+		{"1", 1},
+		{"12", 12},
+		{"1.5", 1.5},
+		{"1.", 1},
+	}
+	for _, test := range tests {
+		tokens, errs := NewLexScanner(test.source).ScanTokens()
+		if len(errs) > 0 {
+			t.Errorf("%q: unexpected errors %v", test.source, errs)
+			continue
+		}
+		if tokens[0].Type != NUMBER || tokens[0].Literal != test.literal {
+			t.Errorf("%q: got %v %v, want NUMBER %v", test.source, tokens[0].Type, tokens[0].Literal, test.literal)
+		}
+		if last := tokens[len(tokens)-1]; last.Type != EOF {
+			t.Errorf("%q: last token is %v, want EOF", test.source, last.Type)
+		}
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	tests := []struct {
+		source string
+		want   []ScanError
+	}{
+		{"@", []ScanError{{Message: "unexpected character '@'", Line: 1, Column: 1}}},
+		{"var s = \"open", []ScanError{{Message: "unterminated string", Line: 1, Column: 9}}},
+		{"1;\n  99999999999999999999", []ScanError{{Message: "integer literal out of range", Line: 2, Column: 3}}},
+		{"# $", []ScanError{
+			{Message: "unexpected character '#'", Line: 1, Column: 1},
+			{Message: "unexpected character '$'", Line: 1, Column: 3},
+		}},
+	}
+	for _, test := range tests {
+		tokens, errs := NewLexScanner(test.source).ScanTokens()
+		if !slices.Equal(errs, test.want) {
+			t.Errorf("%q: got errors %v, want %v", test.source, errs, test.want)
+		}
+		if last := tokens[len(tokens)-1]; last.Type != EOF {
+			t.Errorf("%q: last token is %v, want EOF", test.source, last.Type)
+		}
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	tokens, _ := NewLexScanner("var x;\n  print \"é\";").ScanTokens()
+	want := []struct {
+		lexeme               string
+		line, column, offset int
+	}{
+		{"var", 1, 1, 0}, {"x", 1, 5, 4}, {";", 1, 6, 5},
+		{"print", 2, 3, 9}, {`"é"`, 2, 9, 15}, {";", 2, 13, 19},
+	}
+	for idx, w := range want {
+		got := tokens[idx]
+		if got.Lexeme != w.lexeme || got.Line != w.line || got.Column != w.column || got.Offset != w.offset {
+			t.Errorf("token %d: got %q at %d:%d+%d, want %q at %d:%d+%d",
+				idx, got.Lexeme, got.Line, got.Column, got.Offset, w.lexeme, w.line, w.column, w.offset)
+		}
+		if got.Length != len(w.lexeme) {
+			t.Errorf("token %d: got length %d, want %d", idx, got.Length, len(w.lexeme))
+		}
+	}
+}
+
+func TestSpanMerge(t *testing.T) {
+	tokens, _ := NewLexScanner("a +\n b").ScanTokens()
+	span := tokens[0].Span().Merge(tokens[2].Span())
+	want := Span{Line: 1, Column: 1, Start: 0, End: 6}
+	if span != want {
+		t.Errorf("got %+v, want %+v", span, want)
+	}
+}